@@ -2,18 +2,51 @@ package main
 
 import (
 	"encoding/csv"
+	"errors"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
-	"time"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"pricl_algoritmi/internal/seedheader"
+	"pricl_algoritmi/internal/spanningtree"
 )
 
-func gRGraph(numNodes int, connectivity float64) map[int][]int {
-	rand.NewSource(time.Now().UnixNano())
+func gRGraph(seed int64, numNodes int, connectivity float64) map[int][]int {
+	rng := rand.New(rand.NewSource(seed))
+	graph := make(map[int][]int)
+	for i := 0; i < numNodes; i++ {
+		for j := i + 1; j < numNodes; j++ {
+			if rng.Float64() < connectivity {
+				graph[i] = append(graph[i], j)
+				graph[j] = append(graph[j], i)
+			}
+		}
+	}
+	return graph
+}
+
+// connectedGraph — связный вариант gRGraph: сначала случайное остовное дерево,
+// затем оставшиеся пары соединяются с такой вероятностью, чтобы ожидаемое
+// число рёбер, как и в gRGraph, было connectivity·n(n-1)/2 (но не меньше n-1)
+func connectedGraph(seed int64, numNodes int, connectivity float64) map[int][]int {
+	rng := rand.New(rand.NewSource(seed))
 	graph := make(map[int][]int)
+	inTree := make(map[[2]int]bool, numNodes)
+	tree := spanningtree.Random(rng, numNodes)
+	for _, e := range tree {
+		graph[e[0]] = append(graph[e[0]], e[1])
+		graph[e[1]] = append(graph[e[1]], e[0])
+		inTree[[2]int{min(e[0], e[1]), max(e[0], e[1])}] = true
+	}
+	p := spanningtree.ResidualProbability(connectivity, numNodes*(numNodes-1)/2, len(tree))
 	for i := 0; i < numNodes; i++ {
 		for j := i + 1; j < numNodes; j++ {
-			if rand.Float64() < connectivity {
+			if !inTree[[2]int{i, j}] && rng.Float64() < p {
 				graph[i] = append(graph[i], j)
 				graph[j] = append(graph[j], i)
 			}
@@ -22,39 +55,397 @@ func gRGraph(numNodes int, connectivity float64) map[int][]int {
 	return graph
 }
 
-func saveGrCSV(graph map[int][]int, filename string) error {
+// sortedNodes возвращает вершины-ключи графа по возрастанию
+func sortedNodes(graph map[int][]int) []int {
+	nodes := make([]int, 0, len(graph))
+	for node := range graph {
+		nodes = append(nodes, node)
+	}
+	sort.Ints(nodes)
+	return nodes
+}
+
+// checkWeightRange проверяет, что диапазон весов [minWeight, maxWeight] не пуст
+func checkWeightRange(minWeight, maxWeight int) error {
+	if minWeight > maxWeight {
+		return fmt.Errorf("пустой диапазон весов [%d, %d]", minWeight, maxWeight)
+	}
+	return nil
+}
+
+// randomWeight возвращает случайный вес из [minWeight, maxWeight]
+func randomWeight(rng *rand.Rand, minWeight, maxWeight int) int {
+	return minWeight + rng.Intn(maxWeight-minWeight+1)
+}
+
+// assignWeights назначает каждому ребру случайный вес из [minWeight, maxWeight].
+// Ключ неориентированного ребра — пара (меньшая вершина, большая вершина).
+func assignWeights(rng *rand.Rand, graph map[int][]int, directed bool, minWeight, maxWeight int) map[[2]int]int {
+	weights := make(map[[2]int]int)
+	for _, u := range sortedNodes(graph) {
+		for _, v := range graph[u] {
+			if !directed && u > v {
+				continue
+			}
+			weights[[2]int{u, v}] = randomWeight(rng, minWeight, maxWeight)
+		}
+	}
+	return weights
+}
+
+// floatWeights переводит целые веса рёбер в вещественные, общие для всех форматов вывода
+func floatWeights(weights map[[2]int]int) map[[2]int]float64 {
+	result := make(map[[2]int]float64, len(weights))
+	for key, w := range weights {
+		result[key] = float64(w)
+	}
+	return result
+}
+
+// weightRand возвращает генератор весов рёбер. Его поток не пересекается с потоком
+// генератора графа, поэтому флаг -weighted не меняет сам граф при том же зерне.
+func weightRand(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(chunkSeed(seed, -1)))
+}
+
+// saveEdgeListCSV сохраняет граф списком рёбер "u,v" или, если weights != nil, "u,v,вес";
+// взвешенный список читает ReadGraph из 3_Kruskal
+func saveEdgeListCSV(graph map[int][]int, weights map[[2]int]float64, directed bool, filename, header string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	if header != "" {
+		if _, err := fmt.Fprintln(file, header); err != nil {
+			return err
+		}
+	}
+
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	for node, neighbors := range graph {
-		record := []string{fmt.Sprintf("%d", node)}
-		for _, neighbor := range neighbors {
-			record = append(record, fmt.Sprintf("%d", neighbor))
+	for _, u := range sortedNodes(graph) {
+		for _, v := range graph[u] {
+			if !directed && u > v {
+				continue
+			}
+			record := []string{strconv.Itoa(u), strconv.Itoa(v)}
+			if weights != nil {
+				record = append(record, strconv.FormatFloat(weights[[2]int{u, v}], 'g', -1, 64))
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
 		}
-		if err := writer.Write(record); err != nil {
-			return err
+	}
+	return nil
+}
+
+// grInfo собирает заголовок grcsv из заголовка seedheader.Format с параметрами генерации
+func grInfo(n int, directed bool, header string) grHeader {
+	fields := strings.Fields(strings.TrimPrefix(header, "#"))
+	info := grHeader{N: n, Directed: directed}
+	for _, field := range fields {
+		if value, ok := strings.CutPrefix(field, "seed="); ok {
+			info.Seed, _ = strconv.ParseInt(value, 10, 64)
+		} else if key, _, _ := strings.Cut(field, "="); !grReservedKeys[key] {
+			info.Params = append(info.Params, field)
+		}
+	}
+	return info
+}
+
+// printReport выводит статистику графа или, для format == "adjacency", его списки смежности
+func printReport(graph map[int][]int, n int, directed bool, format string) {
+	if format == "adjacency" {
+		for _, node := range sortedNodes(graph) {
+			fmt.Printf("%d: %v\n", node, graph[node])
 		}
+		return
+	}
+	if err := writeStats(os.Stdout, computeStats(graph, n, directed), format); err != nil {
+		fmt.Println("ошибка вывода отчёта:", err)
+	}
+}
+
+// headerParam подставляет в dst значение поля key из заголовка, если оно там есть
+func headerParam(params map[string]string, key string, dst any) error {
+	value, ok := params[key]
+	if !ok {
+		return nil
+	}
+	var err error
+	switch d := dst.(type) {
+	case *int:
+		*d, err = strconv.Atoi(value)
+	case *int64:
+		*d, err = strconv.ParseInt(value, 10, 64)
+	case *float64:
+		*d, err = strconv.ParseFloat(value, 64)
+	case *bool:
+		*d, err = strconv.ParseBool(value)
+	case *string:
+		*d = value
+	default:
+		return fmt.Errorf("неподдерживаемый тип параметра %s", key)
+	}
+	if err != nil {
+		return fmt.Errorf("неверное значение %s=%q в заголовке: %w", key, value, err)
 	}
 	return nil
 }
 
+// saveGraph записывает граф на вершинах 0..n-1 в файл выбранного формата
+func saveGraph(format string, graph map[int][]int, weights map[[2]int]float64, n int, directed bool, filename, header string) error {
+	switch format {
+	case "grcsv":
+		return saveGrCSV(graph, weights, grInfo(n, directed, header), filename)
+	case "edges":
+		return saveEdgeListCSV(graph, weights, directed, filename, header)
+	case "dijkstra":
+		return saveDijkstraCSV(n, graph, weights, directed, filename, header)
+	}
+	return fmt.Errorf("неизвестный формат файла: %q", format)
+}
+
 func main() {
-	numNodes := 10      // кол-во узлов
-	connectivity := 0.3 // вероятность
+	cfg, err := parseConfig(os.Args[1:])
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Println("ошибка в параметрах:", err)
+		os.Exit(2)
+	}
+	if err := run(cfg); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
 
-	graph := gRGraph(numNodes, connectivity)
+// run строит граф по параметрам cfg, сохраняет его и выводит отчёт
+func run(cfg config) error {
+	seed := cfg.seed
+	numNodes := cfg.numNodes
+	directed := cfg.isDirected()
+	format := cfg.outputFormat()
 
-	if err := saveGrCSV(graph, "rand_graf.csv"); err != nil {
-		fmt.Println("ошибка сохранения в CSV:", err)
+	var graph map[int][]int
+	var weights map[[2]int]float64
+	var intWeights map[[2]int]int
+	var params []string
+	var err error
+	n := numNodes // число вершин в файле; у части моделей отличается от numNodes
+	switch cfg.model {
+	case "gnp":
+		if cfg.connected {
+			graph = connectedGraph(seed, numNodes, cfg.connectivity)
+		} else {
+			graph = gRGraph(seed, numNodes, cfg.connectivity)
+		}
+		params = []string{
+			"model=gnp",
+			"numNodes=" + strconv.Itoa(numNodes),
+			"connectivity=" + strconv.FormatFloat(cfg.connectivity, 'g', -1, 64),
+			"connected=" + strconv.FormatBool(cfg.connected),
+		}
+	case "gnm":
+		graph, err = gnmGraph(seed, numNodes, cfg.numEdges, directed)
+		params = []string{
+			"model=gnm",
+			"numNodes=" + strconv.Itoa(numNodes),
+			"numEdges=" + strconv.Itoa(cfg.numEdges),
+			"directed=" + strconv.FormatBool(directed),
+		}
+	case "ba":
+		// Веса модели Барабаши–Альберт берутся из потока самого генератора,
+		// как до появления общего флага -weighted
+		if cfg.weighted {
+			graph, intWeights, err = barabasiAlbertWeighted(seed, numNodes, cfg.m0, cfg.attach, directed, cfg.minWeight, cfg.maxWeight)
+		} else {
+			graph, err = barabasiAlbert(seed, numNodes, cfg.m0, cfg.attach, directed)
+		}
+		params = []string{
+			"model=ba",
+			"numNodes=" + strconv.Itoa(numNodes),
+			"m0=" + strconv.Itoa(cfg.m0),
+			"attach=" + strconv.Itoa(cfg.attach),
+			"directed=" + strconv.FormatBool(directed),
+		}
+	case "ws":
+		graph, err = wattsStrogatz(seed, numNodes, cfg.k, cfg.beta)
+		params = []string{
+			"model=ws",
+			"numNodes=" + strconv.Itoa(numNodes),
+			"k=" + strconv.Itoa(cfg.k),
+			"beta=" + strconv.FormatFloat(cfg.beta, 'g', -1, 64),
+		}
+	case "geo", "planar":
+		// Весами служат длины рёбер
+		var points []point
+		if cfg.model == "geo" {
+			points, graph, weights, err = geometricGraph(seed, numNodes, cfg.radius, cfg.torus)
+			params = []string{
+				"model=geo",
+				"numNodes=" + strconv.Itoa(numNodes),
+				"radius=" + strconv.FormatFloat(cfg.radius, 'g', -1, 64),
+				"torus=" + strconv.FormatBool(cfg.torus),
+			}
+		} else {
+			points, graph, weights, err = planarGraph(seed, numNodes, cfg.deleteProb)
+			params = []string{
+				"model=planar",
+				"numNodes=" + strconv.Itoa(numNodes),
+				"deleteProb=" + strconv.FormatFloat(cfg.deleteProb, 'g', -1, 64),
+			}
+		}
+		if err == nil {
+			if err := saveCoordinatesCSV(points, cfg.companionFile("coords")); err != nil {
+				return fmt.Errorf("ошибка сохранения координат: %w", err)
+			}
+		}
+	case "regular":
+		if cfg.bipartite {
+			graph, err = randomRegularBipartite(seed, numNodes, cfg.degree)
+			n = 2 * numNodes
+		} else {
+			graph, err = randomRegular(seed, numNodes, cfg.degree)
+		}
+		params = []string{
+			"model=regular",
+			"numNodes=" + strconv.Itoa(numNodes),
+			"d=" + strconv.Itoa(cfg.degree),
+			"bipartite=" + strconv.FormatBool(cfg.bipartite),
+		}
+	case "sbm":
+		blockSizes, err := parseIntList(cfg.blocks)
+		if err != nil {
+			return fmt.Errorf("ошибка в размерах блоков: %w", err)
+		}
+		probs, err := parseProbMatrix(cfg.blockProbs)
+		if err != nil {
+			return fmt.Errorf("ошибка в матрице вероятностей: %w", err)
+		}
+		var labels []int
+		if graph, labels, err = stochasticBlockModel(seed, blockSizes, probs); err != nil {
+			return fmt.Errorf("ошибка генерации графа: %w", err)
+		}
+		n = len(labels)
+		if err := saveLabelsCSV(labels, cfg.companionFile("labels")); err != nil {
+			return fmt.Errorf("ошибка сохранения меток сообществ: %w", err)
+		}
+		params = []string{
+			"model=sbm",
+			"blocks=" + cfg.blocks,
+			"probs=" + cfg.blockProbs,
+		}
+	case "dag":
+		if cfg.numLayers > 0 {
+			graph, intWeights, _, err = layeredDAG(seed, numNodes, cfg.numLayers, cfg.connectivity, cfg.minWeight, cfg.maxWeight)
+		} else {
+			graph, intWeights, _, err = randomDAG(seed, numNodes, cfg.connectivity, cfg.minWeight, cfg.maxWeight)
+		}
+		params = []string{
+			"model=dag",
+			"numNodes=" + strconv.Itoa(numNodes),
+			"connectivity=" + strconv.FormatFloat(cfg.connectivity, 'g', -1, 64),
+			"layers=" + strconv.Itoa(cfg.numLayers),
+		}
+	case "tree":
+		graph, intWeights, _, err = plantedTree(seed, numNodes, cfg.noise, cfg.minWeight, cfg.maxWeight)
+		params = []string{
+			"model=tree",
+			"numNodes=" + strconv.Itoa(numNodes),
+			"noise=" + strconv.Itoa(cfg.noise),
+		}
+	case "degree":
+		degrees, err := parseIntList(cfg.degreeSeq)
+		if err != nil {
+			return fmt.Errorf("ошибка в последовательности степеней: %w", err)
+		}
+		if graph, err = randomDegreeGraph(seed, degrees, cfg.swaps); err != nil {
+			return fmt.Errorf("ошибка генерации графа: %w", err)
+		}
+		n = len(degrees)
+		params = []string{
+			"model=degree",
+			"degrees=" + cfg.degreeSeq,
+			"swaps=" + strconv.Itoa(cfg.swaps),
+		}
+	case "rmat":
+		// Дуги пишутся в файл по мере генерации, без сборки в списки смежности.
+		// Число горутин не влияет на результат, поэтому в заголовок не пишется.
+		if err := checkRmatParams(cfg.scale, cfg.edgeFactor, cfg.rmatA, cfg.rmatB, cfg.rmatC); err != nil {
+			return fmt.Errorf("ошибка генерации графа: %w", err)
+		}
+		params = append([]string{
+			"model=rmat",
+			"scale=" + strconv.Itoa(cfg.scale),
+			"edgeFactor=" + strconv.Itoa(cfg.edgeFactor),
+			"a=" + strconv.FormatFloat(cfg.rmatA, 'g', -1, 64),
+			"b=" + strconv.FormatFloat(cfg.rmatB, 'g', -1, 64),
+			"c=" + strconv.FormatFloat(cfg.rmatC, 'g', -1, 64),
+		}, cfg.weightParams()...)
+		header := seedheader.Format(seed, append(params, "format="+format)...)
+		written, err := saveEdgeListDijkstraCSV(1<<cfg.scale, func(emit func(e weightedEdge) error) error {
+			return rmatEdges(seed, cfg.scale, cfg.edgeFactor, cfg.rmatA, cfg.rmatB, cfg.rmatC, cfg.minWeight, cfg.maxWeight, runtime.NumCPU(), emit)
+		}, cfg.out, header)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения в CSV: %w", err)
+		}
+		fmt.Printf("записано дуг: %d\n", written)
+		return nil
+	case "sparse-gnp":
+		// Граф не собирается в памяти: рёбра сразу пишутся в файл списком "u,v"
+		header := seedheader.Format(seed,
+			"model=sparse-gnp",
+			"numNodes="+strconv.Itoa(numNodes),
+			"connectivity="+strconv.FormatFloat(cfg.connectivity, 'g', -1, 64),
+			"format="+format)
+		written, err := saveSparseGnpCSV(seed, numNodes, cfg.connectivity, cfg.out, header)
+		if err != nil {
+			return fmt.Errorf("ошибка сохранения в CSV: %w", err)
+		}
+		fmt.Printf("записано рёбер: %d\n", written)
+		return nil
+	default:
+		var facts graphFacts
+		if graph, facts, err = structuredGraph(cfg.model, numNodes, cfg.rows, cfg.cols, cfg.dim); err != nil {
+			return fmt.Errorf("ошибка построения графа: %w", err)
+		}
+		n = facts.Nodes
+		params = []string{
+			"model=" + cfg.model,
+			"numNodes=" + strconv.Itoa(numNodes),
+			"rows=" + strconv.Itoa(cfg.rows),
+			"cols=" + strconv.Itoa(cfg.cols),
+			"dim=" + strconv.Itoa(cfg.dim),
+		}
+		if cfg.report != "json" {
+			fmt.Printf("узлов: %d, рёбер: %d, диаметр: %d, хроматический индекс: %d, вес MST: %d, поток 0->%d: %d\n",
+				facts.Nodes, facts.Edges, facts.Diameter, facts.ChromaticIndex, facts.MSTWeight, facts.FlowSink, facts.MaxFlow)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("ошибка генерации графа: %w", err)
 	}
 
-	for node, neighbors := range graph {
-		fmt.Printf("%d: %v\n", node, neighbors)
+	switch {
+	case intWeights != nil:
+		weights = floatWeights(intWeights)
+	case weights == nil && cfg.weighted:
+		weights = floatWeights(assignWeights(weightRand(seed), graph, directed, cfg.minWeight, cfg.maxWeight))
 	}
+	params = append(params, cfg.weightParams()...)
+	header := seedheader.Format(seed, append(params, "format="+format)...)
+	if err := saveGraph(format, graph, weights, n, directed, cfg.out, header); err != nil {
+		return fmt.Errorf("ошибка сохранения в CSV: %w", err)
+	}
+
+	printReport(graph, n, directed, cfg.report)
+	return nil
 }
@@ -0,0 +1,1093 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// countEdges считает рёбра графа; в неориентированном графе каждое ребро хранится дважды
+func countEdges(graph map[int][]int, directed bool) int {
+	total := 0
+	for _, neighbors := range graph {
+		total += len(neighbors)
+	}
+	if !directed {
+		total /= 2
+	}
+	return total
+}
+
+// checkSimple проверяет, что в графе нет петель и кратных рёбер
+func checkSimple(t *testing.T, graph map[int][]int) {
+	t.Helper()
+	for u, neighbors := range graph {
+		seen := make(map[int]bool)
+		for _, v := range neighbors {
+			if u == v {
+				t.Errorf("Обнаружена петля в вершине %d", u)
+			}
+			if seen[v] {
+				t.Errorf("Обнаружено кратное ребро %d-%d", u, v)
+			}
+			seen[v] = true
+		}
+	}
+}
+
+func TestGnmGraph(t *testing.T) {
+	tests := []struct {
+		numNodes, numEdges int
+		directed           bool
+	}{
+		{10, 15, false},
+		{10, 44, false}, // почти полный граф: выбирается дополнение
+		{10, 45, false},
+		{10, 0, false},
+		{8, 30, true},
+		{8, 56, true},
+	}
+
+	for _, tt := range tests {
+		graph, err := gnmGraph(1, tt.numNodes, tt.numEdges, tt.directed)
+		if err != nil {
+			t.Fatalf("gnmGraph(%d, %d, %v): %v", tt.numNodes, tt.numEdges, tt.directed, err)
+		}
+		if got := countEdges(graph, tt.directed); got != tt.numEdges {
+			t.Errorf("gnmGraph(%d, %d, %v): ожидалось %d рёбер, получено %d",
+				tt.numNodes, tt.numEdges, tt.directed, tt.numEdges, got)
+		}
+		checkSimple(t, graph)
+		for u := range graph {
+			if u < 0 || u >= tt.numNodes {
+				t.Errorf("Вершина %d вне диапазона [0, %d)", u, tt.numNodes)
+			}
+		}
+	}
+}
+
+func TestGnmGraphTooManyEdges(t *testing.T) {
+	if _, err := gnmGraph(1, 5, 11, false); err == nil {
+		t.Error("Ожидалась ошибка для 11 рёбер в неориентированном графе из 5 вершин")
+	}
+	if _, err := gnmGraph(1, 5, 21, true); err == nil {
+		t.Error("Ожидалась ошибка для 21 ребра в ориентированном графе из 5 вершин")
+	}
+}
+
+func TestDecodeEdgeIndex(t *testing.T) {
+	numNodes := 6
+	k := int64(0)
+	for i := 0; i < numNodes; i++ {
+		for j := i + 1; j < numNodes; j++ {
+			if u, v := decodeEdgeIndex(k, numNodes, false); u != i || v != j {
+				t.Errorf("Номер %d: ожидалось ребро %d-%d, получено %d-%d", k, i, j, u, v)
+			}
+			k++
+		}
+	}
+}
+
+func TestSparseGnpMatchesBounds(t *testing.T) {
+	numNodes := 2000
+	connectivity := 0.01
+	seen := make(map[[2]int]bool)
+	err := sparseGnp(5, numNodes, connectivity, func(u, v int) error {
+		if v < 0 || v >= u || u >= numNodes {
+			t.Fatalf("Ребро %d-%d вне допустимого диапазона", u, v)
+		}
+		if seen[[2]int{u, v}] {
+			t.Fatalf("Ребро %d-%d порождено дважды", u, v)
+		}
+		seen[[2]int{u, v}] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ожидается p·n(n-1)/2 ≈ 19990 рёбер, стандартное отклонение ≈ 140
+	expected := connectivity * float64(numNodes*(numNodes-1)/2)
+	if got := float64(len(seen)); got < expected-1000 || got > expected+1000 {
+		t.Errorf("Ожидалось около %.0f рёбер, получено %.0f", expected, got)
+	}
+}
+
+// TestSparseGnpTinyProbability проверяет, что огромный пропуск не переполняет int
+func TestSparseGnpTinyProbability(t *testing.T) {
+	numNodes := 5
+	for _, connectivity := range []float64{1e-17, 1e-300, math.SmallestNonzeroFloat64} {
+		for seed := int64(0); seed < 20; seed++ {
+			err := sparseGnp(seed, numNodes, connectivity, func(u, v int) error {
+				if v < 0 || v >= u || u >= numNodes {
+					t.Fatalf("p=%g, seed=%d: ребро %d-%d вне допустимого диапазона", connectivity, seed, u, v)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestSparseGnpExtremes(t *testing.T) {
+	for _, tt := range []struct {
+		connectivity float64
+		expected     int
+	}{{0, 0}, {1, 45}} {
+		count := 0
+		if err := sparseGnp(1, 10, tt.connectivity, func(u, v int) error { count++; return nil }); err != nil {
+			t.Fatal(err)
+		}
+		if count != tt.expected {
+			t.Errorf("p=%g: ожидалось %d рёбер, получено %d", tt.connectivity, tt.expected, count)
+		}
+	}
+	if err := sparseGnp(1, 10, 1.5, func(u, v int) error { return nil }); err == nil {
+		t.Error("Ожидалась ошибка для вероятности больше 1")
+	}
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	numNodes, m0, m := 200, 4, 3
+	graph, err := barabasiAlbert(2, numNodes, m0, m, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSimple(t, graph)
+
+	expected := m0*(m0-1)/2 + (numNodes-m0)*m
+	if got := countEdges(graph, false); got != expected {
+		t.Errorf("Ожидалось %d рёбер, получено %d", expected, got)
+	}
+	for u := m0; u < numNodes; u++ {
+		if len(graph[u]) < m {
+			t.Errorf("Степень узла %d меньше m=%d: %d", u, m, len(graph[u]))
+		}
+	}
+}
+
+func TestBarabasiAlbertWeightedDirected(t *testing.T) {
+	graph, weights, err := barabasiAlbertWeighted(3, 50, 2, 2, true, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for u, neighbors := range graph {
+		for _, v := range neighbors {
+			if u >= 2 && v >= u {
+				t.Errorf("Ребро %d->%d должно вести к более старому узлу", u, v)
+			}
+			if w := weights[[2]int{u, v}]; w < 1 || w > 10 {
+				t.Errorf("Вес ребра %d->%d вне [1, 10]: %d", u, v, w)
+			}
+		}
+	}
+	if _, err := barabasiAlbert(1, 10, 2, 3, false); err == nil {
+		t.Error("Ожидалась ошибка для m > m0")
+	}
+}
+
+func TestWattsStrogatz(t *testing.T) {
+	numNodes, k := 30, 4
+
+	// При beta = 0 получается кольцевая решётка: у каждого узла соседи u±1 и u±2
+	lattice, err := wattsStrogatz(1, numNodes, k, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for u := 0; u < numNodes; u++ {
+		expected := []int{(u + 1) % numNodes, (u + 2) % numNodes, (u + numNodes - 1) % numNodes, (u + numNodes - 2) % numNodes}
+		neighbors := make(map[int]bool)
+		for _, v := range lattice[u] {
+			neighbors[v] = true
+		}
+		for _, v := range expected {
+			if !neighbors[v] {
+				t.Errorf("В решётке нет ребра %d-%d", u, v)
+			}
+		}
+	}
+
+	rewired, err := wattsStrogatz(1, numNodes, k, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSimple(t, rewired)
+	if got := countEdges(rewired, false); got != numNodes*k/2 {
+		t.Errorf("Перестройка должна сохранять число рёбер %d, получено %d", numNodes*k/2, got)
+	}
+
+	if _, err := wattsStrogatz(1, 10, 3, 0.1); err == nil {
+		t.Error("Ожидалась ошибка для нечётного k")
+	}
+}
+
+func TestGeometricGraph(t *testing.T) {
+	for _, torus := range []bool{false, true} {
+		radius := 0.1
+		points, graph, weights, err := geometricGraph(4, 300, radius, torus)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSimple(t, graph)
+
+		// Сверяем сетку ячеек с полным перебором пар
+		edges := 0
+		for u := range points {
+			for v := u + 1; v < len(points); v++ {
+				d := distance(points[u], points[v], torus)
+				w, ok := weights[[2]int{u, v}]
+				if (d < radius) != ok {
+					t.Errorf("torus=%v: ребро %d-%d длины %g найдено неверно", torus, u, v, d)
+				}
+				if ok {
+					edges++
+					if w != d {
+						t.Errorf("torus=%v: вес ребра %d-%d %g, ожидалось %g", torus, u, v, w, d)
+					}
+				}
+			}
+		}
+		if got := countEdges(graph, false); got != edges {
+			t.Errorf("torus=%v: ожидалось %d рёбер, получено %d", torus, edges, got)
+		}
+	}
+}
+
+// TestGeometricGraphTinyRadius проверяет, что сетка ячеек не растёт как 1/radius²
+func TestGeometricGraphTinyRadius(t *testing.T) {
+	for _, radius := range []float64{1e-9, math.SmallestNonzeroFloat64} {
+		points, graph, weights, err := geometricGraph(4, 1000, radius, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(points) != 1000 || len(graph) != 1000 {
+			t.Errorf("radius=%g: ожидалось 1000 узлов, получено %d", radius, len(graph))
+		}
+		if len(weights) != 0 {
+			t.Errorf("radius=%g: ожидалось 0 рёбер, получено %d", radius, len(weights))
+		}
+	}
+}
+
+func TestRandomRegular(t *testing.T) {
+	for _, tt := range []struct{ numNodes, d int }{{10, 3}, {50, 4}, {30, 15}, {6, 5}} {
+		graph, err := randomRegular(7, tt.numNodes, tt.d)
+		if err != nil {
+			t.Fatalf("randomRegular(%d, %d): %v", tt.numNodes, tt.d, err)
+		}
+		checkSimple(t, graph)
+		for u := 0; u < tt.numNodes; u++ {
+			if len(graph[u]) != tt.d {
+				t.Errorf("randomRegular(%d, %d): степень узла %d равна %d", tt.numNodes, tt.d, u, len(graph[u]))
+			}
+		}
+	}
+
+	if _, err := randomRegular(1, 7, 3); err == nil {
+		t.Error("Ожидалась ошибка для нечётного n·d")
+	}
+}
+
+func TestRandomRegularBipartite(t *testing.T) {
+	numPerSide, d := 20, 4
+	graph, err := randomRegularBipartite(7, numPerSide, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSimple(t, graph)
+	for u := 0; u < 2*numPerSide; u++ {
+		if len(graph[u]) != d {
+			t.Errorf("Степень узла %d равна %d, ожидалось %d", u, len(graph[u]), d)
+		}
+		for _, v := range graph[u] {
+			if (u < numPerSide) == (v < numPerSide) {
+				t.Errorf("Ребро %d-%d внутри одной доли", u, v)
+			}
+		}
+	}
+}
+
+func TestStochasticBlockModel(t *testing.T) {
+	blockSizes := []int{20, 30}
+	probs, err := parseProbMatrix("1,0;0,1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Вероятности 1 внутри блоков и 0 между ними дают два полных графа
+	graph, labels, err := stochasticBlockModel(1, blockSizes, probs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 50 {
+		t.Fatalf("Ожидалось 50 меток, получено %d", len(labels))
+	}
+	for u, neighbors := range graph {
+		if len(neighbors) != blockSizes[labels[u]]-1 {
+			t.Errorf("Узел %d блока %d имеет степень %d", u, labels[u], len(neighbors))
+		}
+		for _, v := range neighbors {
+			if labels[u] != labels[v] {
+				t.Errorf("Ребро %d-%d между разными блоками", u, v)
+			}
+		}
+	}
+
+	if _, _, err := stochasticBlockModel(1, blockSizes, [][]float64{{0.5, 0.1}, {0.2, 0.5}}); err == nil {
+		t.Error("Ожидалась ошибка для несимметричной матрицы")
+	}
+}
+
+// isConnected проверяет связность неориентированного графа на узлах 0..numNodes-1
+func isConnected(graph map[int][]int, numNodes int) bool {
+	if numNodes == 0 {
+		return true
+	}
+	visited := map[int]bool{0: true}
+	stack := []int{0}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, v := range graph[u] {
+			if !visited[v] {
+				visited[v] = true
+				stack = append(stack, v)
+			}
+		}
+	}
+	return len(visited) == numNodes
+}
+
+func TestConnectedGraph(t *testing.T) {
+	numNodes := 40
+	for seed := int64(0); seed < 20; seed++ {
+		// При нулевой вероятности остаётся только остовное дерево
+		tree := connectedGraph(seed, numNodes, 0)
+		if !isConnected(tree, numNodes) {
+			t.Errorf("seed=%d: граф несвязен", seed)
+		}
+		if got := countEdges(tree, false); got != numNodes-1 {
+			t.Errorf("seed=%d: ожидалось %d рёбер дерева, получено %d", seed, numNodes-1, got)
+		}
+		checkSimple(t, connectedGraph(seed, numNodes, 0.1))
+	}
+
+	// Рёбра дерева входят в целевую плотность, а не добавляются сверх неё:
+	// прежде при n=200, p=0.05 получалось около 1184 рёбер вместо 995
+	numNodes = 200
+	want := 0.05 * float64(numNodes*(numNodes-1)/2)
+	got := float64(countEdges(connectedGraph(1, numNodes, 0.05), false))
+	if got < 0.9*want || got > 1.1*want {
+		t.Errorf("ожидалось около %.0f рёбер, получено %.0f", want, got)
+	}
+}
+
+func TestRandomDAG(t *testing.T) {
+	graph, weights, order, err := randomDAG(3, 30, 0.3, 1, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	position := make(map[int]int, len(order))
+	for i, u := range order {
+		position[u] = i
+	}
+	for u, neighbors := range graph {
+		for _, v := range neighbors {
+			if position[u] >= position[v] {
+				t.Errorf("Дуга %d->%d идёт против топологического порядка", u, v)
+			}
+			if w := weights[[2]int{u, v}]; w < 1 || w > 50 {
+				t.Errorf("Вес дуги %d->%d вне [1, 50]: %d", u, v, w)
+			}
+		}
+	}
+}
+
+func TestLayeredDAG(t *testing.T) {
+	numNodes, numLayers := 40, 6
+	graph, _, layers, err := layeredDAG(5, numNodes, numLayers, 0.2, 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layers) != numLayers {
+		t.Fatalf("Ожидалось %d слоёв, получено %d", numLayers, len(layers))
+	}
+
+	layerOf := make(map[int]int)
+	total := 0
+	for l, layer := range layers {
+		if len(layer) == 0 {
+			t.Errorf("Слой %d пуст", l)
+		}
+		total += len(layer)
+		for _, u := range layer {
+			layerOf[u] = l
+		}
+	}
+	if total != numNodes {
+		t.Errorf("В слоях %d узлов, ожидалось %d", total, numNodes)
+	}
+
+	hasParent := make(map[int]bool)
+	for u, neighbors := range graph {
+		for _, v := range neighbors {
+			if layerOf[v] != layerOf[u]+1 {
+				t.Errorf("Дуга %d->%d не ведёт в следующий слой", u, v)
+			}
+			hasParent[v] = true
+		}
+	}
+	for _, layer := range layers[1:] {
+		for _, v := range layer {
+			if !hasParent[v] {
+				t.Errorf("У узла %d нет входящих дуг", v)
+			}
+		}
+	}
+}
+
+func TestPruferRoundTrip(t *testing.T) {
+	// Дерево-звезда с центром 3 имеет последовательность из одних троек
+	star := map[int][]int{0: {3}, 1: {3}, 2: {3}, 3: {0, 1, 2, 4}, 4: {3}}
+	seq, err := pruferEncode(star, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seq) != 3 || seq[0] != 3 || seq[1] != 3 || seq[2] != 3 {
+		t.Errorf("Ожидалась последовательность [3 3 3], получено %v", seq)
+	}
+
+	for seed := int64(0); seed < 10; seed++ {
+		numNodes := 25
+		graph, _, err := pruferTree(seed, numNodes, 1, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !isConnected(graph, numNodes) || countEdges(graph, false) != numNodes-1 {
+			t.Fatalf("seed=%d: результат не является деревом", seed)
+		}
+
+		seq, err := pruferEncode(graph, numNodes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		edges, err := pruferDecode(seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, e := range edges {
+			found := false
+			for _, v := range graph[e[0]] {
+				found = found || v == e[1]
+			}
+			if !found {
+				t.Errorf("seed=%d: после декодирования появилось ребро %d-%d, которого нет в дереве", seed, e[0], e[1])
+			}
+		}
+	}
+
+	cycle := map[int][]int{0: {1, 2}, 1: {0, 2}, 2: {0, 1}, 3: nil}
+	if _, err := pruferEncode(cycle, 4); err == nil {
+		t.Error("Ожидалась ошибка для графа с циклом")
+	}
+	outside := map[int][]int{0: {5}, 1: {0}}
+	if _, err := pruferEncode(outside, 2); err == nil {
+		t.Error("Ожидалась ошибка для соседа вне диапазона узлов")
+	}
+	asymmetric := map[int][]int{2: {0, 1}, 0: {1, 2}, 1: {}}
+	if _, err := pruferEncode(asymmetric, 3); err == nil {
+		t.Error("Ожидалась ошибка для несимметричных списков смежности")
+	}
+	for _, graph := range []map[int][]int{{0: {0, 1}, 1: {0}}, {0: {1, 1}, 1: {}, 2: {}}} {
+		if _, err := pruferEncode(graph, 3); err == nil {
+			t.Errorf("Ожидалась ошибка для петли или кратного ребра: %v", graph)
+		}
+	}
+}
+
+func TestPlantedTree(t *testing.T) {
+	maxWeight := 20
+	graph, weights, tree, err := plantedTree(2, 30, 50, 1, maxWeight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countEdges(graph, false); got != 29+50 {
+		t.Errorf("Ожидалось %d рёбер, получено %d", 29+50, got)
+	}
+	inTree := make(map[[2]int]bool)
+	for _, e := range tree {
+		inTree[e] = true
+		if weights[e] > maxWeight {
+			t.Errorf("Вес ребра дерева %v больше %d", e, maxWeight)
+		}
+	}
+	for e, w := range weights {
+		if !inTree[e] && w <= maxWeight {
+			t.Errorf("Шумовое ребро %v легче рёбер дерева: %d", e, w)
+		}
+	}
+}
+
+// bfsEccentricity возвращает наибольшее расстояние от start до остальных узлов
+func bfsEccentricity(graph map[int][]int, start int) int {
+	dist := map[int]int{start: 0}
+	queue := []int{start}
+	farthest := 0
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range graph[u] {
+			if _, seen := dist[v]; !seen {
+				dist[v] = dist[u] + 1
+				farthest = max(farthest, dist[v])
+				queue = append(queue, v)
+			}
+		}
+	}
+	return farthest
+}
+
+// unitMaxFlow считает максимальный поток из s в t, когда каждое ребро
+// неориентированного графа имеет пропускную способность 1 в обе стороны
+func unitMaxFlow(graph map[int][]int, s, t int) int {
+	residual := make(map[[2]int]int)
+	for u, neighbors := range graph {
+		for _, v := range neighbors {
+			residual[[2]int{u, v}] = 1
+		}
+	}
+	flow := 0
+	for {
+		parent := map[int]int{s: s}
+		queue := []int{s}
+		for len(queue) > 0 && !containsKey(parent, t) {
+			u := queue[0]
+			queue = queue[1:]
+			for _, v := range graph[u] {
+				if !containsKey(parent, v) && residual[[2]int{u, v}] > 0 {
+					parent[v] = u
+					queue = append(queue, v)
+				}
+			}
+		}
+		if !containsKey(parent, t) {
+			return flow
+		}
+		for v := t; v != s; v = parent[v] {
+			residual[[2]int{parent[v], v}]--
+			residual[[2]int{v, parent[v]}]++
+		}
+		flow++
+	}
+}
+
+func containsKey(m map[int]int, key int) bool {
+	_, ok := m[key]
+	return ok
+}
+
+func TestStructuredGraphs(t *testing.T) {
+	tests := []struct {
+		name                      string
+		numNodes, rows, cols, dim int
+	}{
+		{"grid", 0, 1, 5, 0},
+		{"grid", 0, 2, 2, 0},
+		{"grid", 0, 4, 6, 0},
+		{"torus", 0, 4, 6, 0},
+		{"torus", 0, 3, 5, 0},
+		{"hypercube", 0, 0, 0, 4},
+		{"complete", 6, 0, 0, 0},
+		{"complete", 7, 0, 0, 0},
+		{"complete-bipartite", 0, 3, 5, 0},
+		{"cycle", 7, 0, 0, 0},
+		{"cycle", 8, 0, 0, 0},
+		{"wheel", 3, 0, 0, 0},
+		{"wheel", 6, 0, 0, 0},
+		{"star", 5, 0, 0, 0},
+		{"petersen", 0, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		graph, facts, err := structuredGraph(tt.name, tt.numNodes, tt.rows, tt.cols, tt.dim)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		checkSimple(t, graph)
+
+		if len(graph) != facts.Nodes {
+			t.Errorf("%s: ожидалось %d узлов, получено %d", tt.name, facts.Nodes, len(graph))
+		}
+		if got := countEdges(graph, false); got != facts.Edges {
+			t.Errorf("%s: ожидалось %d рёбер, получено %d", tt.name, facts.Edges, got)
+		}
+		if !isConnected(graph, facts.Nodes) || facts.MSTWeight != facts.Nodes-1 {
+			t.Errorf("%s: вес MST %d не равен n-1 = %d связного графа", tt.name, facts.MSTWeight, facts.Nodes-1)
+		}
+
+		diameter, maxDegree := 0, 0
+		for u := range graph {
+			diameter = max(diameter, bfsEccentricity(graph, u))
+			maxDegree = max(maxDegree, len(graph[u]))
+		}
+		if diameter != facts.Diameter {
+			t.Errorf("%s: ожидался диаметр %d, получено %d", tt.name, facts.Diameter, diameter)
+		}
+		// По теореме Визинга хроматический индекс равен Δ или Δ+1
+		if facts.ChromaticIndex != maxDegree && facts.ChromaticIndex != maxDegree+1 {
+			t.Errorf("%s: хроматический индекс %d противоречит Δ = %d", tt.name, facts.ChromaticIndex, maxDegree)
+		}
+		if got := unitMaxFlow(graph, 0, facts.FlowSink); got != facts.MaxFlow {
+			t.Errorf("%s: ожидался поток %d, получено %d", tt.name, facts.MaxFlow, got)
+		}
+	}
+}
+
+func TestIsGraphical(t *testing.T) {
+	tests := []struct {
+		degrees   []int
+		graphical bool
+	}{
+		{[]int{3, 3, 3, 3}, true},
+		{[]int{3, 3, 2, 2, 2, 1, 1}, true},
+		{[]int{3, 3, 1, 1}, false}, // двум узлам степени 3 не хватает соседей
+		{[]int{2, 2, 1}, false},    // нечётная сумма
+		{[]int{4, 1, 1, 1}, false}, // степень больше n-1
+		{[]int{}, true},
+	}
+	for _, tt := range tests {
+		if got := isGraphical(tt.degrees); got != tt.graphical {
+			t.Errorf("isGraphical(%v) = %v, ожидалось %v", tt.degrees, got, tt.graphical)
+		}
+	}
+}
+
+func TestDegreeSequenceRealizations(t *testing.T) {
+	degrees := []int{5, 4, 4, 3, 3, 3, 2, 2, 1, 1}
+	for _, swaps := range []int{0, 500} {
+		graph, err := randomDegreeGraph(9, degrees, swaps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSimple(t, graph)
+		for u, d := range degrees {
+			if len(graph[u]) != d {
+				t.Errorf("swaps=%d: степень узла %d равна %d, ожидалось %d", swaps, u, len(graph[u]), d)
+			}
+		}
+	}
+
+	if _, err := havelHakimi([]int{3, 3, 1, 1}); err == nil {
+		t.Error("Ожидалась ошибка для неграфической последовательности")
+	}
+}
+
+// naiveIsGraphical — критерий Эрдёша–Галлаи за O(n²) прямо по определению
+func naiveIsGraphical(degrees []int) bool {
+	sorted := append([]int(nil), degrees...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	total := 0
+	for _, d := range sorted {
+		if d < 0 || d >= max(len(sorted), 1) {
+			return false
+		}
+		total += d
+	}
+	if total%2 != 0 {
+		return false
+	}
+	prefix := 0
+	for k := 1; k <= len(sorted); k++ {
+		prefix += sorted[k-1]
+		rest := 0
+		for _, d := range sorted[k:] {
+			rest += min(d, k)
+		}
+		if prefix > k*(k-1)+rest {
+			return false
+		}
+	}
+	return true
+}
+
+// TestHavelHakimiRandomSequences сверяет линейный критерий с определением
+// и проверяет, что каждая графическая последовательность реализуется
+func TestHavelHakimiRandomSequences(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+	for iter := 0; iter < 2000; iter++ {
+		n := 1 + rng.Intn(12)
+		degrees := make([]int, n)
+		for i := range degrees {
+			degrees[i] = rng.Intn(n)
+		}
+		want := naiveIsGraphical(degrees)
+		if got := isGraphical(degrees); got != want {
+			t.Fatalf("isGraphical(%v) = %v, ожидалось %v", degrees, got, want)
+		}
+		if !want {
+			continue
+		}
+		graph, err := havelHakimi(degrees)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkSimple(t, graph)
+		for u, d := range degrees {
+			if len(graph[u]) != d {
+				t.Fatalf("havelHakimi(%v): у узла %d степень %d, ожидалось %d", degrees, u, len(graph[u]), d)
+			}
+		}
+	}
+}
+
+// collectRmat собирает дуги rmatEdges в срез
+func collectRmat(t *testing.T, seed int64, scale, edgeFactor, workers int) []weightedEdge {
+	t.Helper()
+	var edges []weightedEdge
+	err := rmatEdges(seed, scale, edgeFactor, 0.57, 0.19, 0.19, 1, 100, workers, func(e weightedEdge) error {
+		edges = append(edges, e)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return edges
+}
+
+func TestRmatEdgesDeterministic(t *testing.T) {
+	scale, edgeFactor := 12, 20 // больше одного куска рёбер
+	single := collectRmat(t, 11, scale, edgeFactor, 1)
+	parallel := collectRmat(t, 11, scale, edgeFactor, 8)
+	if len(single) != edgeFactor<<scale {
+		t.Fatalf("Ожидалось %d дуг, получено %d", edgeFactor<<scale, len(single))
+	}
+	for i := range single {
+		if single[i] != parallel[i] {
+			t.Fatalf("Дуга %d зависит от числа горутин: %v и %v", i, single[i], parallel[i])
+		}
+		if e := single[i]; e.From < 0 || e.From >= 1<<scale || e.To < 0 || e.To >= 1<<scale {
+			t.Fatalf("Дуга %v вне диапазона вершин", e)
+		}
+	}
+
+	noop := func(weightedEdge) error { return nil }
+	if err := rmatEdges(1, 4, 2, 0.6, 0.3, 0.3, 1, 10, 1, noop); err == nil {
+		t.Error("Ожидалась ошибка для вероятностей с суммой больше 1")
+	}
+
+	// Ошибка записи останавливает генерацию
+	stop := errors.New("stop")
+	written := 0
+	err := rmatEdges(1, scale, edgeFactor, 0.57, 0.19, 0.19, 1, 10, 4, func(weightedEdge) error {
+		written++
+		if written == 10 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || written != 10 {
+		t.Errorf("Ожидалась остановка на 10-й дуге, получено %d дуг и ошибка %v", written, err)
+	}
+}
+
+func TestRmatScrambleIsBijection(t *testing.T) {
+	for scale := 0; scale <= 12; scale++ {
+		keys := rmatScrambleKeys(int64(scale))
+		seen := make([]bool, 1<<scale)
+		for v := range seen {
+			w := rmatScramble(v, scale, keys)
+			if w < 0 || w >= len(seen) || seen[w] {
+				t.Fatalf("scale=%d: rmatScramble(%d) = %d не биекция", scale, v, w)
+			}
+			seen[w] = true
+		}
+	}
+}
+
+// convexHullSize считает точки на выпуклой оболочке (алгоритм Эндрю)
+func convexHullSize(points []point) int {
+	sorted := append([]point(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	cross := func(o, a, b point) float64 {
+		return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+	}
+	var hull []point
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for _, p := range sorted {
+			for len(hull) >= start+2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+	return len(hull)
+}
+
+// TestPlanarGraphDegenerate проверяет, что без триангуляции точки соединяются путём
+func TestPlanarGraphDegenerate(t *testing.T) {
+	for numNodes := 0; numNodes < 3; numNodes++ {
+		_, graph, weights, err := planarGraph(1, numNodes, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(weights) != max(numNodes-1, 0) || !isConnected(graph, numNodes) {
+			t.Errorf("n=%d: ожидался путь, получено %d рёбер", numNodes, len(weights))
+		}
+	}
+
+	// Точки на одной прямой соединяются в порядке следования вдоль неё
+	points := []point{{0.5, 0.5}, {0.1, 0.1}, {0.9, 0.9}, {0.3, 0.3}}
+	if triangles := delaunayTriangles(points); len(triangles) != 0 {
+		t.Fatalf("Для точек на прямой ожидалось 0 треугольников, получено %d", len(triangles))
+	}
+	want := [][2]int{{1, 3}, {0, 3}, {0, 2}}
+	if got := linePath(points); !reflect.DeepEqual(got, want) {
+		t.Errorf("linePath = %v, ожидалось %v", got, want)
+	}
+}
+
+func TestDelaunayTriangles(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		numNodes := 150
+		points, graph, _, err := planarGraph(seed, numNodes, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		triangles := delaunayTriangles(points)
+
+		// Пустая описанная окружность у каждого треугольника
+		for _, tr := range triangles {
+			for p := range points {
+				if p != tr[0] && p != tr[1] && p != tr[2] &&
+					inCircumcircle(points[tr[0]], points[tr[1]], points[tr[2]], points[p]) {
+					t.Fatalf("seed=%d: точка %d внутри описанной окружности треугольника %v", seed, p, tr)
+				}
+			}
+		}
+
+		// Для триангуляции с h точками на выпуклой оболочке T = 2n-2-h и E = 3n-3-h
+		hull := convexHullSize(points)
+		if len(triangles) != 2*numNodes-2-hull {
+			t.Errorf("seed=%d: %d треугольников, ожидалось %d", seed, len(triangles), 2*numNodes-2-hull)
+		}
+		if got := countEdges(graph, false); got != 3*numNodes-3-hull {
+			t.Errorf("seed=%d: %d рёбер при %d треугольниках, ожидалось %d", seed, got, len(triangles), 3*numNodes-3-hull)
+		}
+		if !isConnected(graph, numNodes) {
+			t.Errorf("seed=%d: триангуляция несвязна", seed)
+		}
+	}
+
+	_, sparse, _, err := planarGraph(1, 150, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countEdges(sparse, false); got > 3*150-6 {
+		t.Errorf("В планарном графе не может быть %d рёбер", got)
+	}
+}
+
+func TestGrCSVRoundTrip(t *testing.T) {
+	filename := "test_grcsv.csv"
+	defer os.Remove(filename)
+
+	// Вершины 3 и 5 изолированы и не являются ключами map
+	graph := map[int][]int{0: {1, 2}, 1: {0}, 2: {0, 4}, 4: {2}}
+	info := grHeader{N: 6, Seed: 17, Params: []string{"model=test", "p=0.5"}}
+	if err := saveGrCSV(graph, nil, info, filename); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := os.ReadFile(filename)
+
+	readGraph, readWeights, readInfo, err := readGrCSV(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if readWeights != nil || readInfo.N != 6 || readInfo.Seed != 17 || readInfo.Directed || readInfo.Weighted {
+		t.Errorf("Неверный заголовок после чтения: %+v", readInfo)
+	}
+	if len(readInfo.Params) != 2 || readInfo.Params[0] != "model=test" || readInfo.Params[1] != "p=0.5" {
+		t.Errorf("Параметры генерации не сохранились: %v", readInfo.Params)
+	}
+	if len(readGraph) != 6 || len(readGraph[3]) != 0 || len(readGraph[5]) != 0 {
+		t.Errorf("Изолированные вершины потеряны: %v", readGraph)
+	}
+
+	if err := saveGrCSV(readGraph, readWeights, readInfo, filename); err != nil {
+		t.Fatal(err)
+	}
+	second, _ := os.ReadFile(filename)
+	if !bytes.Equal(first, second) {
+		t.Errorf("Файл изменился после чтения и записи:\n%s\n%s", first, second)
+	}
+}
+
+func TestGrCSVWeightedDirected(t *testing.T) {
+	filename := "test_grcsv_weighted.csv"
+	defer os.Remove(filename)
+
+	graph := map[int][]int{0: {1}, 1: {0, 2}}
+	weights := map[[2]int]float64{{0, 1}: 1.5, {1, 0}: 2, {1, 2}: 0.25}
+	if err := saveGrCSV(graph, weights, grHeader{N: 3, Directed: true, Seed: 1}, filename); err != nil {
+		t.Fatal(err)
+	}
+
+	_, readWeights, info, err := readGrCSV(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.Directed || !info.Weighted {
+		t.Errorf("Неверный заголовок после чтения: %+v", info)
+	}
+	for key, w := range weights {
+		if readWeights[key] != w {
+			t.Errorf("Вес дуги %v: ожидалось %g, получено %g", key, w, readWeights[key])
+		}
+	}
+
+	// Испорченное число рёбер в заголовке должно обнаруживаться
+	data, _ := os.ReadFile(filename)
+	os.WriteFile(filename, bytes.Replace(data, []byte("m=3"), []byte("m=4"), 1), 0644)
+	if _, _, _, err := readGrCSV(filename); err == nil {
+		t.Error("Ожидалась ошибка при несовпадении m с файлом")
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	// K_5 и отдельное ребро 5-6, вершина 7 изолирована
+	graph, _, _ := completeGraph(5)
+	graph[5] = []int{6}
+	graph[6] = []int{5}
+	stats := computeStats(graph, 8, false)
+
+	if stats.Nodes != 8 || stats.Edges != 11 {
+		t.Errorf("Ожидалось 8 вершин и 11 рёбер, получено %d и %d", stats.Nodes, stats.Edges)
+	}
+	if stats.DegreeHistogram[4] != 5 || stats.DegreeHistogram[1] != 2 || stats.DegreeHistogram[0] != 1 {
+		t.Errorf("Неверная гистограмма степеней: %v", stats.DegreeHistogram)
+	}
+	if stats.Components != 3 || stats.ComponentSizes[0] != 5 || stats.ComponentSizes[1] != 2 || stats.ComponentSizes[2] != 1 {
+		t.Errorf("Неверные компоненты: %d %v", stats.Components, stats.ComponentSizes)
+	}
+	if stats.Triangles != 10 {
+		t.Errorf("В K_5 10 треугольников, получено %d", stats.Triangles)
+	}
+	// У вершин K_5 коэффициент 1, у остальных 0
+	if expected := 5.0 / 8; stats.AvgClustering != expected {
+		t.Errorf("Ожидался средний коэффициент кластеризации %g, получено %g", expected, stats.AvgClustering)
+	}
+	if stats.Diameter != 1 || !stats.DiameterIsExact {
+		t.Errorf("Ожидался точный диаметр 1, получено %d", stats.Diameter)
+	}
+
+	petersen, facts, _ := petersenGraph()
+	if s := computeStats(petersen, facts.Nodes, false); s.Triangles != 0 || s.Diameter != facts.Diameter {
+		t.Errorf("Граф Петерсена: треугольников %d, диаметр %d", s.Triangles, s.Diameter)
+	}
+
+	var buf bytes.Buffer
+	if err := writeStats(&buf, stats, "json"); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte(`"triangles": 10`)) {
+		t.Errorf("В JSON-отчёте нет числа треугольников:\n%s", buf.String())
+	}
+}
+
+func TestParseConfigValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"-model", "foo"},
+		{"-numNodes", "-1"},
+		{"-connectivity", "1.5"},
+		{"-weighted", "-minWeight", "10", "-maxWeight", "5"},
+		{"-model", "ws", "-directed"},
+		{"-format", "xml"},
+		{"-report", "yaml"},
+		{"-model", "rmat", "-format", "grcsv"},
+		{"-model", "sparse-gnp", "-format", "grcsv"},
+		{"-format", "dijkstra"},
+		{"-model", "geo", "-format", "dijkstra"},
+		{"-model", "ba", "-connected"},
+		{"-out", ""},
+		{"лишний"},
+	} {
+		if _, err := parseConfig(args); err == nil {
+			t.Errorf("Ожидалась ошибка для %v", args)
+		}
+	}
+
+	cfg, err := parseConfig([]string{"-model", "gnm", "-numNodes", "20", "-numEdges", "30", "-directed", "-weighted", "-minWeight", "5", "-maxWeight", "9"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.numNodes != 20 || cfg.numEdges != 30 || !cfg.isDirected() || cfg.outputFormat() != "grcsv" {
+		t.Errorf("Неверно разобраны параметры: %+v", cfg)
+	}
+	if cfg, _ := parseConfig([]string{"-model", "dag"}); cfg.outputFormat() != "dijkstra" {
+		t.Errorf("Для dag ожидался формат dijkstra, получен %s", cfg.outputFormat())
+	}
+	// Взвешенные графы по умолчанию пишутся в grcsv, который хранит изолированные вершины
+	for _, model := range []string{"tree", "geo", "planar"} {
+		if cfg, _ := parseConfig([]string{"-model", model}); cfg.outputFormat() != "grcsv" {
+			t.Errorf("Для %s ожидался формат grcsv, получен %s", model, cfg.outputFormat())
+		}
+	}
+	if cfg, _ := parseConfig([]string{"-model", "sparse-gnp"}); cfg.outputFormat() != "edges" {
+		t.Errorf("Для sparse-gnp ожидался формат edges, получен %s", cfg.outputFormat())
+	}
+}
+
+// TestRunReplay проверяет, что -replay воспроизводит файл байт в байт в каждом формате
+func TestRunReplay(t *testing.T) {
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"-model", "gnp", "-numNodes", "15", "-weighted", "-minWeight", "-5", "-maxWeight", "5"},
+		{"-model", "gnm", "-numEdges", "20", "-directed", "-format", "grcsv"},
+		{"-model", "ba", "-numNodes", "20", "-attach", "3", "-weighted", "-format", "dijkstra"},
+		{"-model", "regular", "-numNodes", "6", "-bipartite", "-format", "edges"},
+		{"-model", "dag", "-numNodes", "12", "-layers", "3", "-format", "grcsv"},
+		{"-model", "grid"},
+	} {
+		first := filepath.Join(dir, "first.csv")
+		cfg, err := parseConfig(append(args, "-seed", "11", "-out", first, "-report", "json"))
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		if err := run(cfg); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+
+		second := filepath.Join(dir, "second.csv")
+		cfg, err = parseConfig([]string{"-replay", first, "-out", second, "-report", "json"})
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		if err := run(cfg); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+
+		a, _ := os.ReadFile(first)
+		b, _ := os.ReadFile(second)
+		if !bytes.Equal(a, b) {
+			t.Errorf("%v: воспроизведённый файл отличается:\n%s\n%s", args, a, b)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// barabasiAlbert строит безмасштабный граф модели Барабаши–Альберт.
+// Начальные m0 узлов образуют полный граф, затем каждый новый узел соединяется
+// с m различными уже существующими узлами, выбранными с вероятностью,
+// пропорциональной их степени. В ориентированном варианте рёбра идут
+// от нового узла к выбранным, а притяжение считается по полной степени.
+func barabasiAlbert(seed int64, numNodes, m0, m int, directed bool) (map[int][]int, error) {
+	return barabasiAlbertRand(rand.New(rand.NewSource(seed)), numNodes, m0, m, directed)
+}
+
+// barabasiAlbertWeighted — barabasiAlbert со случайными весами рёбер из [minWeight, maxWeight]
+func barabasiAlbertWeighted(seed int64, numNodes, m0, m int, directed bool, minWeight, maxWeight int) (map[int][]int, map[[2]int]int, error) {
+	if err := checkWeightRange(minWeight, maxWeight); err != nil {
+		return nil, nil, err
+	}
+	rng := rand.New(rand.NewSource(seed))
+	graph, err := barabasiAlbertRand(rng, numNodes, m0, m, directed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return graph, assignWeights(rng, graph, directed, minWeight, maxWeight), nil
+}
+
+func barabasiAlbertRand(rng *rand.Rand, numNodes, m0, m int, directed bool) (map[int][]int, error) {
+	if m < 1 || m0 < m {
+		return nil, fmt.Errorf("нужно 1 <= m <= m0, получено m0=%d, m=%d", m0, m)
+	}
+	if numNodes < m0 {
+		return nil, fmt.Errorf("число узлов %d меньше начального m0=%d", numNodes, m0)
+	}
+
+	graph := make(map[int][]int)
+	// Каждая вершина входит в endpoints столько раз, какова её степень,
+	// поэтому равномерный выбор из endpoints — это выбор пропорционально степени
+	endpoints := make([]int, 0, 2*(m0*(m0-1)/2+(numNodes-m0)*m))
+	addEdge := func(u, v int) {
+		graph[u] = append(graph[u], v)
+		if !directed {
+			graph[v] = append(graph[v], u)
+		}
+		endpoints = append(endpoints, u, v)
+	}
+
+	for u := 0; u < m0; u++ {
+		for v := u + 1; v < m0; v++ {
+			addEdge(u, v)
+		}
+	}
+
+	targets := make([]int, 0, m)
+	chosen := make(map[int]bool, m)
+	for u := m0; u < numNodes; u++ {
+		targets = targets[:0]
+		clear(chosen)
+		for len(targets) < m {
+			var v int
+			if len(endpoints) == 0 {
+				// Начальный граф из одного узла не имеет рёбер — выбираем равномерно
+				v = rng.Intn(u)
+			} else {
+				v = endpoints[rng.Intn(len(endpoints))]
+			}
+			if !chosen[v] {
+				chosen[v] = true
+				targets = append(targets, v)
+			}
+		}
+		for _, v := range targets {
+			addEdge(u, v)
+		}
+	}
+	return graph, nil
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"pricl_algoritmi/internal/seedheader"
+)
+
+// config — параметры запуска генератора. Имена флагов совпадают с полями
+// заголовка файла, поэтому -replay просто подставляет заголовок во флаги.
+type config struct {
+	model  string
+	seed   int64
+	replay string
+	out    string
+	format string
+	report string
+
+	numNodes     int
+	connectivity float64
+	numEdges     int
+	directed     bool
+	connected    bool
+	weighted     bool
+	minWeight    int
+	maxWeight    int
+
+	m0         int
+	attach     int
+	k          int
+	beta       float64
+	radius     float64
+	torus      bool
+	degree     int
+	bipartite  bool
+	blocks     string
+	blockProbs string
+	numLayers  int
+	noise      int
+	rows       int
+	cols       int
+	dim        int
+	deleteProb float64
+	degreeSeq  string
+	swaps      int
+
+	scale               int
+	edgeFactor          int
+	rmatA, rmatB, rmatC float64
+}
+
+// randomModels — модели, которые строятся генератором случайных чисел
+var randomModels = []string{"gnp", "gnm", "sparse-gnp", "ba", "ws", "geo", "regular", "sbm", "dag", "tree", "degree", "rmat", "planar"}
+
+// structuredModels — детерминированные семейства графов из structuredGraph
+var structuredModels = []string{"grid", "torus", "hypercube", "complete", "complete-bipartite", "cycle", "wheel", "star", "petersen"}
+
+// outputFormats — форматы выходного файла
+var outputFormats = []string{"grcsv", "edges", "dijkstra"}
+
+// reportFormats — что можно вывести после генерации
+var reportFormats = []string{"text", "json", "adjacency"}
+
+// errUsage означает, что FlagSet уже вывел сообщение об ошибке и справку по флагам
+var errUsage = errors.New("неверные флаги командной строки")
+
+// newFlagSet описывает флаги генератора и связывает их с полями cfg
+func newFlagSet(cfg *config) *flag.FlagSet {
+	fs := flag.NewFlagSet("rand_graf", flag.ContinueOnError)
+
+	fs.StringVar(&cfg.model, "model", "gnp", "модель графа: gnp (G(n,p)), gnm (G(n,m)), sparse-gnp (разреженный G(n,p) без хранения в памяти), ba (Барабаши–Альберт), ws (Уоттс–Строгац), geo (геометрический), regular (d-регулярный), sbm (стохастическая блочная модель), dag (ациклический орграф), tree (дерево Прюфера), degree (по последовательности степеней), rmat (R-MAT), planar (триангуляция Делоне за O(n²), до ~10⁴ узлов); детерминированные: "+strings.Join(structuredModels, ", "))
+	fs.Int64Var(&cfg.seed, "seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	fs.StringVar(&cfg.replay, "replay", "", "CSV-файл, граф из которого нужно воспроизвести по заголовку")
+	fs.StringVar(&cfg.out, "out", "rand_graf.csv", "выходной файл; координаты и метки сообществ пишутся рядом с суффиксами _coords и _labels")
+	fs.StringVar(&cfg.format, "format", "", "формат выходного файла: grcsv (списки смежности), edges (рёбра \"u,v[,вес]\", как у 3_Kruskal), dijkstra (число вершин и дуги \"u,v,вес\", как у 7_Dijkstra); по умолчанию dijkstra для dag и rmat, edges для sparse-gnp, иначе grcsv (он хранит и веса, и изолированные вершины)")
+	fs.StringVar(&cfg.report, "report", "text", "что вывести после генерации: text или json (статистика графа), adjacency (списки смежности)")
+
+	fs.IntVar(&cfg.numNodes, "numNodes", 10, "число узлов")
+	fs.Float64Var(&cfg.connectivity, "connectivity", 0.3, "вероятность ребра (gnp, sparse-gnp, dag)")
+	fs.IntVar(&cfg.numEdges, "numEdges", 15, "число рёбер (gnm)")
+	fs.BoolVar(&cfg.directed, "directed", false, "ориентированный граф (gnm, ba; dag и rmat ориентированы всегда)")
+	fs.BoolVar(&cfg.connected, "connected", false, "строить гарантированно связный граф (gnp)")
+	fs.BoolVar(&cfg.weighted, "weighted", false, "назначить рёбрам случайные веса (tree, dag и rmat взвешены всегда, geo и planar — длинами рёбер)")
+	fs.IntVar(&cfg.minWeight, "minWeight", 1, "минимальный вес ребра")
+	fs.IntVar(&cfg.maxWeight, "maxWeight", 100, "максимальный вес ребра")
+
+	fs.IntVar(&cfg.m0, "m0", 3, "начальное число узлов (ba)")
+	fs.IntVar(&cfg.attach, "attach", 2, "число рёбер нового узла (ba)")
+	fs.IntVar(&cfg.k, "k", 4, "степень кольцевой решётки (ws)")
+	fs.Float64Var(&cfg.beta, "beta", 0.1, "вероятность перестройки ребра (ws)")
+	fs.Float64Var(&cfg.radius, "radius", 0.3, "радиус соединения (geo)")
+	fs.BoolVar(&cfg.torus, "torus", false, "расстояния на торе (geo)")
+	fs.IntVar(&cfg.degree, "d", 3, "степень узлов (regular)")
+	fs.BoolVar(&cfg.bipartite, "bipartite", false, "двудольный граф, numNodes — размер доли (regular)")
+	fs.StringVar(&cfg.blocks, "blocks", "5,5", "размеры блоков через запятую (sbm)")
+	fs.StringVar(&cfg.blockProbs, "probs", "0.6,0.05;0.05,0.6", "матрица вероятностей между блоками, строки через «;» (sbm)")
+	fs.IntVar(&cfg.numLayers, "layers", 0, "число слоёв; 0 — случайный топологический порядок (dag)")
+	fs.IntVar(&cfg.noise, "noise", 0, "число шумовых рёбер поверх дерева (tree)")
+	fs.IntVar(&cfg.rows, "rows", 3, "число строк решётки/тора, размер первой доли K_{a,b}")
+	fs.IntVar(&cfg.cols, "cols", 4, "число столбцов решётки/тора, размер второй доли K_{a,b}")
+	fs.IntVar(&cfg.dim, "dim", 3, "размерность гиперкуба")
+	fs.Float64Var(&cfg.deleteProb, "deleteProb", 0, "вероятность удаления ребра триангуляции (planar)")
+	fs.StringVar(&cfg.degreeSeq, "degrees", "3,3,2,2,2,1,1", "последовательность степеней через запятую (degree)")
+	fs.IntVar(&cfg.swaps, "swaps", 100, "число попыток перестановки рёбер; 0 — граф Гавела–Хакими (degree)")
+
+	fs.IntVar(&cfg.scale, "scale", 10, "2^scale вершин (rmat)")
+	fs.IntVar(&cfg.edgeFactor, "edgeFactor", 16, "число дуг на вершину (rmat)")
+	fs.Float64Var(&cfg.rmatA, "a", 0.57, "вероятность левой верхней четверти (rmat)")
+	fs.Float64Var(&cfg.rmatB, "b", 0.19, "вероятность правой верхней четверти (rmat)")
+	fs.Float64Var(&cfg.rmatC, "c", 0.19, "вероятность левой нижней четверти (rmat); d = 1-a-b-c")
+	return fs
+}
+
+// parseConfig разбирает аргументы командной строки, при -replay подставляет
+// параметры из заголовка файла и проверяет, что их сочетание допустимо
+func parseConfig(args []string) (config, error) {
+	var cfg config
+	fs := newFlagSet(&cfg)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return cfg, err
+		}
+		return cfg, errUsage
+	}
+	if fs.NArg() > 0 {
+		return cfg, fmt.Errorf("лишние аргументы: %v", fs.Args())
+	}
+	if cfg.replay != "" {
+		if err := applyReplayHeader(fs, cfg.replay); err != nil {
+			return cfg, err
+		}
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// applyReplayHeader переносит зерно и параметры генерации из заголовка файла во флаги.
+// Остальные флаги, кроме выходного файла и отчёта, сбрасываются к значениям
+// по умолчанию, поэтому граф определяется только заголовком. Заголовки без поля
+// model записаны генератором G(n,p), а без поля format — в формате по умолчанию для модели.
+func applyReplayHeader(fs *flag.FlagSet, filename string) error {
+	_, params, err := seedheader.Read(filename)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения заголовка: %w", err)
+	}
+	var resetErr error
+	fs.VisitAll(func(f *flag.Flag) {
+		switch f.Name {
+		case "seed", "replay", "out", "report":
+			return
+		}
+		if err := fs.Set(f.Name, f.DefValue); err != nil && resetErr == nil {
+			resetErr = err
+		}
+	})
+	if resetErr != nil {
+		return resetErr
+	}
+
+	grcsv := params["format"] == "grcsv"
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if grcsv && (key == "n" || key == "m" || key == "version") {
+			// Эти поля описывают сам файл grcsv, а не параметры генерации
+			continue
+		}
+		if fs.Lookup(key) == nil {
+			continue
+		}
+		if err := fs.Set(key, params[key]); err != nil {
+			return fmt.Errorf("неверное значение %s=%q в заголовке: %w", key, params[key], err)
+		}
+	}
+	return nil
+}
+
+// validate проверяет параметры, не зависящие от конкретной модели;
+// ограничения самих моделей проверяют их генераторы
+func (cfg config) validate() error {
+	if !contains(randomModels, cfg.model) && !contains(structuredModels, cfg.model) {
+		return fmt.Errorf("неизвестная модель графа: %q", cfg.model)
+	}
+	if cfg.format != "" && !contains(outputFormats, cfg.format) {
+		return fmt.Errorf("неизвестный формат файла %q, допустимы: %s", cfg.format, strings.Join(outputFormats, ", "))
+	}
+	if !contains(reportFormats, cfg.report) {
+		return fmt.Errorf("неизвестный вид отчёта %q, допустимы: %s", cfg.report, strings.Join(reportFormats, ", "))
+	}
+	if cfg.out == "" {
+		return fmt.Errorf("не задан выходной файл")
+	}
+	if cfg.numNodes < 0 {
+		return fmt.Errorf("число узлов не может быть отрицательным: %d", cfg.numNodes)
+	}
+	if cfg.connectivity < 0 || cfg.connectivity > 1 {
+		return fmt.Errorf("вероятность ребра должна лежать в [0, 1]: %g", cfg.connectivity)
+	}
+	if err := checkWeightRange(cfg.minWeight, cfg.maxWeight); err != nil {
+		return err
+	}
+	if cfg.directed && !contains([]string{"gnm", "ba", "dag", "rmat"}, cfg.model) {
+		return fmt.Errorf("модель %s строит только неориентированные графы", cfg.model)
+	}
+	if cfg.connected && cfg.model != "gnp" {
+		return fmt.Errorf("флаг -connected поддерживает только модель gnp")
+	}
+
+	switch format := cfg.outputFormat(); {
+	case cfg.model == "rmat" && format != "dijkstra":
+		return fmt.Errorf("модель rmat пишется только в формате dijkstra")
+	case cfg.model == "sparse-gnp" && format != "edges":
+		return fmt.Errorf("модель sparse-gnp пишется только в формате edges")
+	case format == "dijkstra" && !cfg.hasWeights():
+		return fmt.Errorf("формат dijkstra хранит веса дуг: добавьте -weighted")
+	case format == "dijkstra" && (cfg.model == "geo" || cfg.model == "planar"):
+		return fmt.Errorf("длины рёбер модели %s не целые, формат dijkstra их не сохранит", cfg.model)
+	}
+	return nil
+}
+
+// hasWeights сообщает, будут ли у рёбер графа веса
+func (cfg config) hasWeights() bool {
+	return cfg.weighted || contains([]string{"tree", "dag", "rmat", "geo", "planar"}, cfg.model)
+}
+
+// isDirected сообщает, будет ли граф ориентированным
+func (cfg config) isDirected() bool {
+	return cfg.directed || cfg.model == "dag" || cfg.model == "rmat"
+}
+
+// outputFormat возвращает формат выходного файла с учётом значения по умолчанию для модели
+func (cfg config) outputFormat() string {
+	switch {
+	case cfg.format != "":
+		return cfg.format
+	case cfg.model == "dag" || cfg.model == "rmat":
+		return "dijkstra"
+	case cfg.model == "sparse-gnp":
+		return "edges"
+	default:
+		return "grcsv"
+	}
+}
+
+// weightParams возвращает поля заголовка, описывающие случайные веса рёбер;
+// у невзвешенного графа и у графов с длинами рёбер их нет
+func (cfg config) weightParams() []string {
+	if !cfg.hasWeights() || cfg.model == "geo" || cfg.model == "planar" {
+		return nil
+	}
+	return []string{
+		"weighted=true",
+		fmt.Sprintf("minWeight=%d", cfg.minWeight),
+		fmt.Sprintf("maxWeight=%d", cfg.maxWeight),
+	}
+}
+
+// companionFile возвращает путь файла рядом с выходным: rand_graf.csv -> rand_graf_coords.csv
+func (cfg config) companionFile(suffix string) string {
+	ext := filepath.Ext(cfg.out)
+	return strings.TrimSuffix(cfg.out, ext) + "_" + suffix + ext
+}
+
+// contains сообщает, есть ли value среди values
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+// randomDAG строит случайный взвешенный ориентированный ациклический граф:
+// узлы случайно перемешиваются в топологический порядок, и каждая пара
+// «раньше → позже» соединяется дугой с вероятностью connectivity.
+// Возвращает граф, веса дуг и топологический порядок.
+func randomDAG(seed int64, numNodes int, connectivity float64, minWeight, maxWeight int) (map[int][]int, map[[2]int]int, []int, error) {
+	if err := checkDAGParams(numNodes, connectivity, minWeight, maxWeight); err != nil {
+		return nil, nil, nil, err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	order := rng.Perm(numNodes)
+	graph := make(map[int][]int, numNodes)
+	weights := make(map[[2]int]int)
+	for i, u := range order {
+		graph[u] = nil
+		for _, v := range order[i+1:] {
+			if rng.Float64() < connectivity {
+				graph[u] = append(graph[u], v)
+				weights[[2]int{u, v}] = randomWeight(rng, minWeight, maxWeight)
+			}
+		}
+	}
+	return graph, weights, order, nil
+}
+
+// layeredDAG строит случайный взвешенный DAG из numLayers слоёв. Ширины слоёв —
+// случайное разбиение numNodes на numLayers положительных слагаемых; узлы нумеруются
+// слой за слоем. Дуги идут только из слоя в следующий: каждая пара соединяется
+// с вероятностью connectivity, а у каждого узла не первого слоя есть хотя бы одна
+// входящая дуга, так что длина самого длинного пути ровно numLayers-1.
+// Возвращает граф, веса дуг и номера узлов по слоям.
+func layeredDAG(seed int64, numNodes, numLayers int, connectivity float64, minWeight, maxWeight int) (map[int][]int, map[[2]int]int, [][]int, error) {
+	if err := checkDAGParams(numNodes, connectivity, minWeight, maxWeight); err != nil {
+		return nil, nil, nil, err
+	}
+	if numLayers < 1 || numLayers > numNodes {
+		return nil, nil, nil, fmt.Errorf("число слоёв должно лежать в [1, %d]: %d", numNodes, numLayers)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+
+	// Разрезы выбираются среди numNodes-1 промежутков без повторений
+	cuts := make([]bool, numNodes)
+	for _, c := range rng.Perm(numNodes - 1)[:numLayers-1] {
+		cuts[c+1] = true
+	}
+	layers := make([][]int, 0, numLayers)
+	for u := 0; u < numNodes; u++ {
+		if u == 0 || cuts[u] {
+			layers = append(layers, nil)
+		}
+		layers[len(layers)-1] = append(layers[len(layers)-1], u)
+	}
+
+	graph := make(map[int][]int, numNodes)
+	weights := make(map[[2]int]int)
+	for u := 0; u < numNodes; u++ {
+		graph[u] = nil
+	}
+	for l := 1; l < len(layers); l++ {
+		prev := layers[l-1]
+		for _, v := range layers[l] {
+			hasParent := false
+			for _, u := range prev {
+				if rng.Float64() < connectivity {
+					graph[u] = append(graph[u], v)
+					weights[[2]int{u, v}] = randomWeight(rng, minWeight, maxWeight)
+					hasParent = true
+				}
+			}
+			if !hasParent {
+				u := prev[rng.Intn(len(prev))]
+				graph[u] = append(graph[u], v)
+				weights[[2]int{u, v}] = randomWeight(rng, minWeight, maxWeight)
+			}
+		}
+	}
+	return graph, weights, layers, nil
+}
+
+func checkDAGParams(numNodes int, connectivity float64, minWeight, maxWeight int) error {
+	if numNodes < 1 {
+		return fmt.Errorf("нужен хотя бы один узел, получено %d", numNodes)
+	}
+	if connectivity < 0 || connectivity > 1 {
+		return fmt.Errorf("вероятность дуги должна лежать в [0, 1]: %g", connectivity)
+	}
+	return checkWeightRange(minWeight, maxWeight)
+}
+
+// saveDijkstraCSV сохраняет взвешенный граф в формате Graph.SaveToCSV из 7_Dijkstra:
+// первая строка — число вершин, далее строки "откуда,куда,вес". Ребро неориентированного
+// графа записывается двумя дугами. Формат хранит только целые веса.
+func saveDijkstraCSV(numNodes int, graph map[int][]int, weights map[[2]int]float64, directed bool, filename, header string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if header != "" {
+		if _, err := fmt.Fprintln(file, header); err != nil {
+			return err
+		}
+	}
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{strconv.Itoa(numNodes)}); err != nil {
+		return err
+	}
+	for _, u := range sortedNodes(graph) {
+		for _, v := range graph[u] {
+			key := [2]int{u, v}
+			if !directed {
+				key = [2]int{min(u, v), max(u, v)}
+			}
+			w := weights[key]
+			if w != math.Trunc(w) {
+				return fmt.Errorf("вес %g дуги (%d,%d) не целый", w, u, v)
+			}
+			record := []string{strconv.Itoa(u), strconv.Itoa(v), strconv.FormatFloat(w, 'f', -1, 64)}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
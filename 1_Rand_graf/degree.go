@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// isGraphical проверяет по критерию Эрдёша–Галлаи, существует ли простой граф
+// с данной последовательностью степеней. Степени не больше n-1, поэтому они
+// сортируются подсчётом, и вся проверка занимает O(n).
+func isGraphical(degrees []int) bool {
+	n := len(degrees)
+	count := make([]int, max(n, 1))
+	total := 0
+	for _, d := range degrees {
+		if d < 0 || d >= max(n, 1) {
+			return false
+		}
+		count[d]++
+		total += d
+	}
+	if total%2 != 0 {
+		return false
+	}
+
+	sorted := make([]int, 0, n)
+	for d := len(count) - 1; d >= 0; d-- {
+		for range count[d] {
+			sorted = append(sorted, d)
+		}
+	}
+	// suffix[i] — сумма sorted[i:]
+	suffix := make([]int, n+1)
+	for i := n - 1; i >= 0; i-- {
+		suffix[i] = suffix[i+1] + sorted[i]
+	}
+
+	// Для каждого k сумма k наибольших степеней не больше k(k-1) + Σ min(d, k)
+	// по остальным. Степени не меньше k занимают префикс sorted[:w], и w только
+	// убывает с ростом k: позиции k..w-1 дают по k, а хвост с w — свою сумму.
+	w := n
+	prefix := 0
+	for k := 1; k <= n; k++ {
+		prefix += sorted[k-1]
+		for w > 0 && sorted[w-1] < k {
+			w--
+		}
+		j := max(k, w)
+		if prefix > k*(k-1)+k*(j-k)+suffix[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// havelHakimi строит граф с заданными степенями детерминированно: узел
+// с наибольшей остаточной степенью d соединяется с d следующими по степени.
+// Узел i получает степень degrees[i].
+// Узлы хранятся в корзинах по остаточной степени, поэтому вместо сортировки
+// на каждом шаге просматривается не больше d+1 корзин, и построение занимает O(n+m).
+func havelHakimi(degrees []int) (map[int][]int, error) {
+	if !isGraphical(degrees) {
+		return nil, fmt.Errorf("последовательность %v не графическая", degrees)
+	}
+
+	n := len(degrees)
+	graph := emptyGraph(n)
+	remaining := append([]int(nil), degrees...)
+	// Корзина — стек; узлы кладутся по убыванию номера, чтобы при равных
+	// степенях первым снимался узел с меньшим номером
+	buckets := make([][]int, n)
+	for u := n - 1; u >= 0; u-- {
+		if remaining[u] > 0 {
+			buckets[remaining[u]] = append(buckets[remaining[u]], u)
+		}
+	}
+	pop := func(level int) int {
+		bucket := buckets[level]
+		u := bucket[len(bucket)-1]
+		buckets[level] = bucket[:len(bucket)-1]
+		return u
+	}
+
+	var taken []int
+	for top := n - 1; ; {
+		for top > 0 && len(buckets[top]) == 0 {
+			top--
+		}
+		if top <= 0 {
+			return graph, nil
+		}
+		u := pop(top)
+		d := remaining[u]
+		remaining[u] = 0
+
+		// По теореме Гавела–Хакими после снятия u остаётся графическая
+		// последовательность, так что d узлов с ненулевой степенью найдутся
+		taken = taken[:0]
+		for level := d; len(taken) < d; level-- {
+			for len(taken) < d && len(buckets[level]) > 0 {
+				v := pop(level)
+				addUndirected(graph, u, v)
+				taken = append(taken, v)
+			}
+		}
+		// Снятые узлы возвращаются на уровень ниже только после выбора всех d,
+		// иначе один узел мог бы попасть в соседи дважды
+		for i := len(taken) - 1; i >= 0; i-- {
+			v := taken[i]
+			remaining[v]--
+			if remaining[v] > 0 {
+				buckets[remaining[v]] = append(buckets[remaining[v]], v)
+			}
+		}
+	}
+}
+
+// randomDegreeGraph строит случайную реализацию последовательности степеней:
+// начиная с графа Гавела–Хакими, выполняет swaps попыток двойной замены рёбер
+// (a,b),(c,d) -> (a,d),(c,b), отбрасывая те, что дают петлю или кратное ребро.
+// Степени всех узлов при этом сохраняются.
+func randomDegreeGraph(seed int64, degrees []int, swaps int) (map[int][]int, error) {
+	start, err := havelHakimi(degrees)
+	if err != nil {
+		return nil, err
+	}
+
+	var edges [][2]int
+	exists := make(map[[2]int]bool)
+	for _, u := range sortedNodes(start) {
+		for _, v := range start[u] {
+			if u < v {
+				edges = append(edges, [2]int{u, v})
+				exists[[2]int{u, v}] = true
+			}
+		}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	if len(edges) >= 2 {
+		for i := 0; i < swaps; i++ {
+			x, y := rng.Intn(len(edges)), rng.Intn(len(edges))
+			if x == y {
+				continue
+			}
+			a, b := edges[x][0], edges[x][1]
+			c, d := edges[y][0], edges[y][1]
+			// Случайно выбираем одну из двух возможных перестановок концов
+			if rng.Intn(2) == 0 {
+				c, d = d, c
+			}
+			if a == d || c == b {
+				continue
+			}
+			first, second := [2]int{min(a, d), max(a, d)}, [2]int{min(c, b), max(c, b)}
+			if exists[first] || exists[second] {
+				continue
+			}
+			delete(exists, edges[x])
+			delete(exists, edges[y])
+			edges[x], edges[y] = first, second
+			exists[first] = true
+			exists[second] = true
+		}
+	}
+
+	graph := emptyGraph(len(degrees))
+	for _, e := range edges {
+		addUndirected(graph, e[0], e[1])
+	}
+	for u := range graph {
+		sort.Ints(graph[u])
+	}
+	return graph, nil
+}
+
+// parseIntList разбирает список целых чисел через запятую, например "3,3,2,2"
+func parseIntList(s string) ([]int, error) {
+	fields := strings.Split(s, ",")
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		value, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("неверное число %q", field)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,293 @@
+package main
+
+import "fmt"
+
+// graphFacts — известные заранее характеристики графа из структурного семейства.
+// Остовное дерево считается при единичных весах рёбер, поток — при единичных
+// пропускных способностях из узла 0 в узел FlowSink.
+type graphFacts struct {
+	Nodes          int
+	Edges          int
+	Diameter       int
+	ChromaticIndex int
+	MSTWeight      int
+	FlowSink       int
+	MaxFlow        int
+}
+
+func addUndirected(graph map[int][]int, u, v int) {
+	graph[u] = append(graph[u], v)
+	graph[v] = append(graph[v], u)
+}
+
+func emptyGraph(numNodes int) map[int][]int {
+	graph := make(map[int][]int, numNodes)
+	for u := 0; u < numNodes; u++ {
+		graph[u] = nil
+	}
+	return graph
+}
+
+// gridGraph — решётка rows×cols, узел (i, j) имеет номер i*cols+j
+func gridGraph(rows, cols int) (map[int][]int, graphFacts, error) {
+	if rows < 1 || cols < 1 {
+		return nil, graphFacts{}, fmt.Errorf("размеры решётки должны быть положительными: %d×%d", rows, cols)
+	}
+	graph := emptyGraph(rows * cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if j+1 < cols {
+				addUndirected(graph, i*cols+j, i*cols+j+1)
+			}
+			if i+1 < rows {
+				addUndirected(graph, i*cols+j, (i+1)*cols+j)
+			}
+		}
+	}
+
+	// Решётка двудольна, поэтому хроматический индекс равен максимальной степени
+	maxDegree := min(rows-1, 2) + min(cols-1, 2)
+	maxFlow := 2
+	if rows == 1 || cols == 1 {
+		maxFlow = 1
+	}
+	if rows*cols == 1 {
+		maxFlow = 0
+	}
+	return graph, graphFacts{
+		Nodes:          rows * cols,
+		Edges:          rows*(cols-1) + cols*(rows-1),
+		Diameter:       rows - 1 + cols - 1,
+		ChromaticIndex: maxDegree,
+		MSTWeight:      rows*cols - 1,
+		FlowSink:       rows*cols - 1,
+		MaxFlow:        maxFlow,
+	}, nil
+}
+
+// torusGraph — тор rows×cols (решётка с замкнутыми краями), rows, cols >= 3
+func torusGraph(rows, cols int) (map[int][]int, graphFacts, error) {
+	if rows < 3 || cols < 3 {
+		return nil, graphFacts{}, fmt.Errorf("размеры тора должны быть не меньше 3: %d×%d", rows, cols)
+	}
+	graph := emptyGraph(rows * cols)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			addUndirected(graph, i*cols+j, i*cols+(j+1)%cols)
+			addUndirected(graph, i*cols+j, ((i+1)%rows)*cols+j)
+		}
+	}
+
+	// Произведение циклов 1-го класса, если хотя бы один цикл чётный;
+	// при нечётном числе узлов 4-регулярный граф не раскрасить в 4 цвета
+	chromaticIndex := 4
+	if rows%2 == 1 && cols%2 == 1 {
+		chromaticIndex = 5
+	}
+	return graph, graphFacts{
+		Nodes:          rows * cols,
+		Edges:          2 * rows * cols,
+		Diameter:       rows/2 + cols/2,
+		ChromaticIndex: chromaticIndex,
+		MSTWeight:      rows*cols - 1,
+		FlowSink:       (rows/2)*cols + cols/2,
+		MaxFlow:        4,
+	}, nil
+}
+
+// hypercubeGraph — гиперкуб размерности dim: узлы соседствуют, если их номера отличаются одним битом
+func hypercubeGraph(dim int) (map[int][]int, graphFacts, error) {
+	if dim < 0 || dim > 24 {
+		return nil, graphFacts{}, fmt.Errorf("размерность гиперкуба должна лежать в [0, 24]: %d", dim)
+	}
+	numNodes := 1 << dim
+	graph := emptyGraph(numNodes)
+	for u := 0; u < numNodes; u++ {
+		for bit := 0; bit < dim; bit++ {
+			if v := u ^ (1 << bit); u < v {
+				addUndirected(graph, u, v)
+			}
+		}
+	}
+	return graph, graphFacts{
+		Nodes:          numNodes,
+		Edges:          dim * numNodes / 2,
+		Diameter:       dim,
+		ChromaticIndex: dim,
+		MSTWeight:      numNodes - 1,
+		FlowSink:       numNodes - 1,
+		MaxFlow:        dim,
+	}, nil
+}
+
+// completeGraph — полный граф K_n
+func completeGraph(numNodes int) (map[int][]int, graphFacts, error) {
+	if numNodes < 2 {
+		return nil, graphFacts{}, fmt.Errorf("в полном графе нужно хотя бы 2 узла: %d", numNodes)
+	}
+	graph := emptyGraph(numNodes)
+	for u := 0; u < numNodes; u++ {
+		for v := u + 1; v < numNodes; v++ {
+			addUndirected(graph, u, v)
+		}
+	}
+
+	// K_n с чётным n раскладывается на n-1 совершенных паросочетаний
+	chromaticIndex := numNodes - 1
+	if numNodes%2 == 1 {
+		chromaticIndex = numNodes
+	}
+	return graph, graphFacts{
+		Nodes:          numNodes,
+		Edges:          numNodes * (numNodes - 1) / 2,
+		Diameter:       1,
+		ChromaticIndex: chromaticIndex,
+		MSTWeight:      numNodes - 1,
+		FlowSink:       numNodes - 1,
+		MaxFlow:        numNodes - 1,
+	}, nil
+}
+
+// completeBipartiteGraph — полный двудольный граф K_{a,b}: доли 0..a-1 и a..a+b-1
+func completeBipartiteGraph(a, b int) (map[int][]int, graphFacts, error) {
+	if a < 1 || b < 1 {
+		return nil, graphFacts{}, fmt.Errorf("доли должны быть непустыми: %d и %d", a, b)
+	}
+	graph := emptyGraph(a + b)
+	for u := 0; u < a; u++ {
+		for v := a; v < a+b; v++ {
+			addUndirected(graph, u, v)
+		}
+	}
+	diameter := 2
+	if a == 1 && b == 1 {
+		diameter = 1
+	}
+	return graph, graphFacts{
+		Nodes:          a + b,
+		Edges:          a * b,
+		Diameter:       diameter,
+		ChromaticIndex: max(a, b),
+		MSTWeight:      a + b - 1,
+		FlowSink:       a,
+		MaxFlow:        min(a, b),
+	}, nil
+}
+
+// cycleGraph — цикл C_n
+func cycleGraph(numNodes int) (map[int][]int, graphFacts, error) {
+	if numNodes < 3 {
+		return nil, graphFacts{}, fmt.Errorf("в цикле нужно хотя бы 3 узла: %d", numNodes)
+	}
+	graph := emptyGraph(numNodes)
+	for u := 0; u < numNodes; u++ {
+		addUndirected(graph, u, (u+1)%numNodes)
+	}
+	chromaticIndex := 2
+	if numNodes%2 == 1 {
+		chromaticIndex = 3
+	}
+	return graph, graphFacts{
+		Nodes:          numNodes,
+		Edges:          numNodes,
+		Diameter:       numNodes / 2,
+		ChromaticIndex: chromaticIndex,
+		MSTWeight:      numNodes - 1,
+		FlowSink:       numNodes / 2,
+		MaxFlow:        2,
+	}, nil
+}
+
+// wheelGraph — колесо: центр 0 соединён со всеми узлами обода-цикла 1..spokes
+func wheelGraph(spokes int) (map[int][]int, graphFacts, error) {
+	if spokes < 3 {
+		return nil, graphFacts{}, fmt.Errorf("у колеса должно быть хотя бы 3 спицы: %d", spokes)
+	}
+	graph := emptyGraph(spokes + 1)
+	for i := 1; i <= spokes; i++ {
+		addUndirected(graph, 0, i)
+		addUndirected(graph, i, i%spokes+1)
+	}
+	diameter := 2
+	if spokes == 3 {
+		diameter = 1 // колесо с тремя спицами — это K_4
+	}
+	return graph, graphFacts{
+		Nodes:          spokes + 1,
+		Edges:          2 * spokes,
+		Diameter:       diameter,
+		ChromaticIndex: spokes,
+		MSTWeight:      spokes,
+		FlowSink:       1,
+		MaxFlow:        3,
+	}, nil
+}
+
+// starGraph — звезда: центр 0 и листья 1..leaves
+func starGraph(leaves int) (map[int][]int, graphFacts, error) {
+	if leaves < 1 {
+		return nil, graphFacts{}, fmt.Errorf("у звезды должен быть хотя бы один лист: %d", leaves)
+	}
+	graph := emptyGraph(leaves + 1)
+	for i := 1; i <= leaves; i++ {
+		addUndirected(graph, 0, i)
+	}
+	diameter := 2
+	if leaves == 1 {
+		diameter = 1
+	}
+	return graph, graphFacts{
+		Nodes:          leaves + 1,
+		Edges:          leaves,
+		Diameter:       diameter,
+		ChromaticIndex: leaves,
+		MSTWeight:      leaves,
+		FlowSink:       1,
+		MaxFlow:        1,
+	}, nil
+}
+
+// petersenGraph — граф Петерсена: внешний цикл 0..4, внутренняя пентаграмма 5..9
+func petersenGraph() (map[int][]int, graphFacts, error) {
+	graph := emptyGraph(10)
+	for i := 0; i < 5; i++ {
+		addUndirected(graph, i, (i+1)%5)
+		addUndirected(graph, i, i+5)
+		addUndirected(graph, i+5, (i+2)%5+5)
+	}
+	return graph, graphFacts{
+		Nodes:          10,
+		Edges:          15,
+		Diameter:       2,
+		ChromaticIndex: 4, // граф Петерсена — снарк, 3 цветов не хватает
+		MSTWeight:      9,
+		FlowSink:       7,
+		MaxFlow:        3,
+	}, nil
+}
+
+// structuredGraph строит граф семейства name; numNodes задаёт размер цикла,
+// полного графа, число спиц колеса и листьев звезды
+func structuredGraph(name string, numNodes, rows, cols, dim int) (map[int][]int, graphFacts, error) {
+	switch name {
+	case "grid":
+		return gridGraph(rows, cols)
+	case "torus":
+		return torusGraph(rows, cols)
+	case "hypercube":
+		return hypercubeGraph(dim)
+	case "complete":
+		return completeGraph(numNodes)
+	case "complete-bipartite":
+		return completeBipartiteGraph(rows, cols)
+	case "cycle":
+		return cycleGraph(numNodes)
+	case "wheel":
+		return wheelGraph(numNodes)
+	case "star":
+		return starGraph(numNodes)
+	case "petersen":
+		return petersenGraph()
+	}
+	return nil, graphFacts{}, fmt.Errorf("неизвестное семейство графов: %s", name)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// point — координаты узла в единичном квадрате
+type point struct {
+	X, Y float64
+}
+
+// geometricGraph строит случайный геометрический граф: numNodes точек равномерно
+// в единичном квадрате (или на торе, если torus) соединяются, когда расстояние
+// между ними меньше radius. Вес ребра — евклидово расстояние.
+// Соседи ищутся по сетке ячеек со стороной не меньше radius, поэтому
+// время в среднем O(n+m), а не O(n²).
+func geometricGraph(seed int64, numNodes int, radius float64, torus bool) ([]point, map[int][]int, map[[2]int]float64, error) {
+	if numNodes < 0 {
+		return nil, nil, nil, fmt.Errorf("число узлов не может быть отрицательным: %d", numNodes)
+	}
+	if radius <= 0 {
+		return nil, nil, nil, fmt.Errorf("радиус должен быть положительным: %g", radius)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	points := make([]point, numNodes)
+	for i := range points {
+		points[i] = point{X: rng.Float64(), Y: rng.Float64()}
+	}
+
+	graph := make(map[int][]int, numNodes)
+	weights := make(map[[2]int]float64)
+	for u := 0; u < numNodes; u++ {
+		graph[u] = nil
+	}
+	connect := func(u, v int) {
+		d := distance(points[u], points[v], torus)
+		if d < radius {
+			graph[u] = append(graph[u], v)
+			graph[v] = append(graph[v], u)
+			weights[[2]int{min(u, v), max(u, v)}] = d
+		}
+	}
+
+	// Ячеек не больше, чем нужно для O(1) точек в каждой: при крошечном радиусе
+	// сетка 1/radius × 1/radius не поместилась бы в память
+	cells := int(math.Min(1/radius, math.Sqrt(float64(numNodes))+1))
+	if cells < 3 {
+		// При большом радиусе сетка вырождается, проще перебрать все пары
+		for u := 0; u < numNodes; u++ {
+			for v := u + 1; v < numNodes; v++ {
+				connect(u, v)
+			}
+		}
+	} else {
+		grid := make([][]int, cells*cells)
+		cellOf := func(p point) (int, int) {
+			return min(int(p.X*float64(cells)), cells-1), min(int(p.Y*float64(cells)), cells-1)
+		}
+		for i, p := range points {
+			cx, cy := cellOf(p)
+			grid[cx*cells+cy] = append(grid[cx*cells+cy], i)
+		}
+
+		for u, p := range points {
+			cx, cy := cellOf(p)
+			for dx := -1; dx <= 1; dx++ {
+				for dy := -1; dy <= 1; dy++ {
+					nx, ny := cx+dx, cy+dy
+					if torus {
+						nx, ny = (nx+cells)%cells, (ny+cells)%cells
+					} else if nx < 0 || ny < 0 || nx >= cells || ny >= cells {
+						continue
+					}
+					for _, v := range grid[nx*cells+ny] {
+						if v > u {
+							connect(u, v)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for u := range graph {
+		sort.Ints(graph[u])
+	}
+	return points, graph, weights, nil
+}
+
+// distance — евклидово расстояние; на торе координаты сворачиваются по модулю 1
+func distance(a, b point, torus bool) float64 {
+	dx, dy := math.Abs(a.X-b.X), math.Abs(a.Y-b.Y)
+	if torus {
+		dx, dy = math.Min(dx, 1-dx), math.Min(dy, 1-dy)
+	}
+	return math.Hypot(dx, dy)
+}
+
+// saveCoordinatesCSV сохраняет координаты узлов строками "узел,x,y"
+func saveCoordinatesCSV(points []point, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	for i, p := range points {
+		record := []string{
+			strconv.Itoa(i),
+			strconv.FormatFloat(p.X, 'g', -1, 64),
+			strconv.FormatFloat(p.Y, 'g', -1, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
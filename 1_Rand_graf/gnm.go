@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// gnmGraph строит граф модели Эрдёша–Реньи G(n,m): ровно numEdges рёбер,
+// выбранных равновероятно без повторений среди всех возможных пар вершин.
+// Для ориентированного графа каждая упорядоченная пара (i,j), i != j, — отдельное ребро.
+func gnmGraph(seed int64, numNodes, numEdges int, directed bool) (map[int][]int, error) {
+	if numNodes < 0 || numEdges < 0 {
+		return nil, fmt.Errorf("число узлов и рёбер не может быть отрицательным: n=%d, m=%d", numNodes, numEdges)
+	}
+	maxEdges := int64(numNodes) * int64(numNodes-1)
+	if !directed {
+		maxEdges /= 2
+	}
+	if int64(numEdges) > maxEdges {
+		return nil, fmt.Errorf("в графе из %d узлов не больше %d рёбер, запрошено %d", numNodes, maxEdges, numEdges)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	indices := sampleEdgeIndices(rng, maxEdges, int64(numEdges))
+
+	graph := make(map[int][]int)
+	for _, k := range indices {
+		i, j := decodeEdgeIndex(k, numNodes, directed)
+		graph[i] = append(graph[i], j)
+		if !directed {
+			graph[j] = append(graph[j], i)
+		}
+	}
+	return graph, nil
+}
+
+// sampleEdgeIndices выбирает k различных номеров из [0, total) и возвращает их по возрастанию.
+// Используется алгоритм Флойда; если k больше половины total, выбирается дополнение,
+// поэтому память и время остаются O(min(k, total-k) + k).
+func sampleEdgeIndices(rng *rand.Rand, total, k int64) []int64 {
+	complement := k > total/2
+	pick := k
+	if complement {
+		pick = total - k
+	}
+
+	chosen := make(map[int64]bool, pick)
+	for j := total - pick; j < total; j++ {
+		t := rng.Int63n(j + 1)
+		if chosen[t] {
+			chosen[j] = true
+		} else {
+			chosen[t] = true
+		}
+	}
+
+	indices := make([]int64, 0, k)
+	if complement {
+		for idx := int64(0); idx < total; idx++ {
+			if !chosen[idx] {
+				indices = append(indices, idx)
+			}
+		}
+		return indices
+	}
+	for idx := range chosen {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(a, b int) bool { return indices[a] < indices[b] })
+	return indices
+}
+
+// decodeEdgeIndex переводит номер ребра в пару вершин.
+// Неориентированные рёбра нумеруются по строкам верхнего треугольника матрицы смежности,
+// ориентированные — по строкам матрицы без диагонали.
+func decodeEdgeIndex(k int64, numNodes int, directed bool) (int, int) {
+	n := int64(numNodes)
+	if directed {
+		i := k / (n - 1)
+		j := k % (n - 1)
+		if j >= i {
+			j++
+		}
+		return int(i), int(j)
+	}
+
+	// Строка i содержит n-1-i рёбер; ищем её бинарным поиском по числу рёбер в строках до неё
+	lo, hi := int64(0), n-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if mid*(2*n-mid-1)/2 <= k {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	i := lo
+	j := i + 1 + (k - i*(2*n-i-1)/2)
+	return int(i), int(j)
+}
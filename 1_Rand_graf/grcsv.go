@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"pricl_algoritmi/internal/seedheader"
+)
+
+// grFormatVersion — версия формата списков смежности, который пишет saveGrCSV.
+//
+// Первая строка файла — заголовок-комментарий вида
+//
+//	# seed=42 format=grcsv version=1 n=5 m=4 directed=false weighted=true model=gnp ...
+//
+// за ним ровно n строк по вершинам 0..n-1: номер вершины и её соседи.
+// Во взвешенном графе сосед записывается как "сосед:вес". Изолированная вершина —
+// строка из одного номера. В неориентированном графе каждое ребро есть в списках
+// обоих концов, а m считает его один раз.
+const grFormatVersion = 1
+
+// grHeader — сведения о графе из заголовка файла
+type grHeader struct {
+	N        int
+	Directed bool
+	Weighted bool
+	Seed     int64
+	Params   []string // параметры генерации "ключ=значение" в порядке записи
+}
+
+// grReservedKeys — поля заголовка, которые описывают сам формат, а не параметры генерации
+var grReservedKeys = map[string]bool{
+	"seed": true, "format": true, "version": true, "n": true, "m": true, "directed": true, "weighted": true,
+}
+
+// saveGrCSV сохраняет граф на вершинах 0..info.N-1 в формате grcsv.
+// weights — веса рёбер с ключами как у assignWeights; nil для невзвешенного графа.
+func saveGrCSV(graph map[int][]int, weights map[[2]int]float64, info grHeader, filename string) error {
+	for u := range graph {
+		if u < 0 || u >= info.N {
+			return fmt.Errorf("вершина %d вне [0, %d)", u, info.N)
+		}
+	}
+	arcs := 0
+	for _, neighbors := range graph {
+		arcs += len(neighbors)
+	}
+	numEdges := arcs
+	if !info.Directed {
+		numEdges /= 2
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	fields := append([]string{
+		"format=grcsv",
+		"version=" + strconv.Itoa(grFormatVersion),
+		"n=" + strconv.Itoa(info.N),
+		"m=" + strconv.Itoa(numEdges),
+		"directed=" + strconv.FormatBool(info.Directed),
+		"weighted=" + strconv.FormatBool(weights != nil),
+	}, info.Params...)
+	if _, err := fmt.Fprintln(file, seedheader.Format(info.Seed, fields...)); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	for u := 0; u < info.N; u++ {
+		record := []string{strconv.Itoa(u)}
+		for _, v := range graph[u] {
+			field := strconv.Itoa(v)
+			if weights != nil {
+				key := [2]int{u, v}
+				if !info.Directed {
+					key = [2]int{min(u, v), max(u, v)}
+				}
+				field += ":" + strconv.FormatFloat(weights[key], 'g', -1, 64)
+			}
+			record = append(record, field)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readGrCSV читает граф, сохранённый saveGrCSV, и проверяет его на соответствие заголовку
+func readGrCSV(filename string) (map[int][]int, map[[2]int]float64, grHeader, error) {
+	var info grHeader
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, info, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, nil, info, err
+	}
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "#") {
+		return nil, nil, info, fmt.Errorf("в файле %s нет заголовка grcsv", filename)
+	}
+
+	header := make(map[string]string)
+	for _, field := range strings.Fields(strings.TrimPrefix(line, "#")) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, nil, info, fmt.Errorf("неверное поле заголовка: %q", field)
+		}
+		header[key] = value
+		if !grReservedKeys[key] {
+			info.Params = append(info.Params, field)
+		}
+	}
+	if header["format"] != "grcsv" {
+		return nil, nil, info, fmt.Errorf("файл %s не в формате grcsv", filename)
+	}
+	if header["version"] != strconv.Itoa(grFormatVersion) {
+		return nil, nil, info, fmt.Errorf("неподдерживаемая версия формата grcsv: %q", header["version"])
+	}
+
+	numEdges := 0
+	for key, dst := range map[string]any{
+		"seed":     &info.Seed,
+		"n":        &info.N,
+		"m":        &numEdges,
+		"directed": &info.Directed,
+		"weighted": &info.Weighted,
+	} {
+		if _, ok := header[key]; !ok {
+			return nil, nil, info, fmt.Errorf("в заголовке нет поля %s", key)
+		}
+		if err := headerParam(header, key, dst); err != nil {
+			return nil, nil, info, err
+		}
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, nil, info, err
+	}
+	if len(records) != info.N {
+		return nil, nil, info, fmt.Errorf("в заголовке n=%d, а строк с вершинами %d", info.N, len(records))
+	}
+
+	graph := make(map[int][]int, info.N)
+	var weights map[[2]int]float64
+	if info.Weighted {
+		weights = make(map[[2]int]float64)
+	}
+	arcs := 0
+	for u, record := range records {
+		if record[0] != strconv.Itoa(u) {
+			return nil, nil, info, fmt.Errorf("строка %d описывает вершину %q, ожидалась %d", u+1, record[0], u)
+		}
+		graph[u] = nil
+		for _, field := range record[1:] {
+			target, weight, hasWeight := strings.Cut(field, ":")
+			if hasWeight != info.Weighted {
+				return nil, nil, info, fmt.Errorf("вершина %d: сосед %q не соответствует weighted=%v", u, field, info.Weighted)
+			}
+			v, err := strconv.Atoi(target)
+			if err != nil || v < 0 || v >= info.N {
+				return nil, nil, info, fmt.Errorf("вершина %d: неверный сосед %q", u, target)
+			}
+			graph[u] = append(graph[u], v)
+			arcs++
+			if info.Weighted {
+				w, err := strconv.ParseFloat(weight, 64)
+				if err != nil {
+					return nil, nil, info, fmt.Errorf("вершина %d: неверный вес %q", u, weight)
+				}
+				key := [2]int{u, v}
+				if !info.Directed {
+					key = [2]int{min(u, v), max(u, v)}
+				}
+				weights[key] = w
+			}
+		}
+	}
+
+	if !info.Directed {
+		if arcs%2 != 0 {
+			return nil, nil, info, fmt.Errorf("списки смежности неориентированного графа несимметричны")
+		}
+		arcs /= 2
+	}
+	if arcs != numEdges {
+		return nil, nil, info, fmt.Errorf("в заголовке m=%d, а в списках %d рёбер", numEdges, arcs)
+	}
+	return graph, weights, info, nil
+}
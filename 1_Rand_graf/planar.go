@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// ghost — «бесконечная» вершина. Треугольник (u, v, ghost) прилегает снаружи
+// к ребру выпуклой оболочки u→v, так что оболочка всегда остаётся выпуклой.
+const ghost = -1
+
+// delaunayTriangles строит триангуляцию Делоне точек алгоритмом Боуэра–Уотсона:
+// точки добавляются по одной, треугольники, в чью описанную окружность попала
+// точка, удаляются, а образовавшаяся полость заполняется треугольниками
+// с вершиной в новой точке. Время O(n²).
+// Треугольники возвращаются тройками номеров точек против часовой стрелки.
+func delaunayTriangles(points []point) [][3]int {
+	n := len(points)
+	if n < 3 {
+		return nil
+	}
+
+	// Начинаем с первых двух точек и первой не лежащей с ними на одной прямой
+	third := 2
+	for third < n && orientation(points[0], points[1], points[third]) == 0 {
+		third++
+	}
+	if third == n {
+		return nil
+	}
+	a, b, c := 0, 1, third
+	if orientation(points[a], points[b], points[c]) < 0 {
+		a, b = b, a
+	}
+	triangles := [][3]int{{a, b, c}, {b, a, ghost}, {c, b, ghost}, {a, c, ghost}}
+
+	for p := 2; p < n; p++ {
+		if p == third {
+			continue
+		}
+		var bad, good [][3]int
+		for _, tr := range triangles {
+			if inConflict(points, tr, points[p]) {
+				bad = append(bad, tr)
+			} else {
+				good = append(good, tr)
+			}
+		}
+
+		// Граница полости — рёбра плохих треугольников, не общие для двух из них
+		shared := make(map[[2]int]int)
+		for _, tr := range bad {
+			for i := 0; i < 3; i++ {
+				u, v := tr[i], tr[(i+1)%3]
+				shared[[2]int{min(u, v), max(u, v)}]++
+			}
+		}
+		for _, tr := range bad {
+			for i := 0; i < 3; i++ {
+				u, v := tr[i], tr[(i+1)%3]
+				if shared[[2]int{min(u, v), max(u, v)}] != 1 {
+					continue
+				}
+				// Ребро (u, v) обходится против часовой стрелки, и точка p лежит слева от него
+				switch {
+				case u == ghost:
+					good = append(good, [3]int{v, p, ghost})
+				case v == ghost:
+					good = append(good, [3]int{p, u, ghost})
+				default:
+					good = append(good, [3]int{u, v, p})
+				}
+			}
+		}
+		triangles = good
+	}
+
+	result := triangles[:0]
+	for _, tr := range triangles {
+		if tr[2] != ghost {
+			result = append(result, tr)
+		}
+	}
+	return result
+}
+
+// orientation > 0, если a, b, c идут против часовой стрелки, < 0 — по часовой, 0 — на одной прямой
+func orientation(a, b, c point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// inConflict проверяет, должен ли треугольник tr исчезнуть при вставке точки d.
+// Для обычного треугольника это попадание в описанную окружность, для внешнего
+// (u, v, ghost) — попадание строго левее ребра оболочки u→v или внутрь этого ребра.
+func inConflict(points []point, tr [3]int, d point) bool {
+	if tr[2] != ghost {
+		return inCircumcircle(points[tr[0]], points[tr[1]], points[tr[2]], d)
+	}
+	u, v := points[tr[0]], points[tr[1]]
+	o := orientation(u, v, d)
+	if o != 0 {
+		return o > 0
+	}
+	return (d.X-u.X)*(d.X-v.X)+(d.Y-u.Y)*(d.Y-v.Y) < 0
+}
+
+// inCircumcircle проверяет, лежит ли d строго внутри окружности, описанной
+// около треугольника a, b, c, заданного против часовой стрелки
+func inCircumcircle(a, b, c, d point) bool {
+	ax, ay := a.X-d.X, a.Y-d.Y
+	bx, by := b.X-d.X, b.Y-d.Y
+	cx, cy := c.X-d.X, c.Y-d.Y
+	det := (ax*ax+ay*ay)*(bx*cy-cx*by) -
+		(bx*bx+by*by)*(ax*cy-cx*ay) +
+		(cx*cx+cy*cy)*(ax*by-bx*ay)
+	return det > 0
+}
+
+// linePath соединяет точки путём в порядке возрастания координат. Это вырожденная
+// триангуляция, когда точек меньше трёх или все они лежат на одной прямой.
+func linePath(points []point) [][2]int {
+	order := make([]int, len(points))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := points[order[i]], points[order[j]]
+		if a.X != b.X {
+			return a.X < b.X
+		}
+		return a.Y < b.Y
+	})
+	var edges [][2]int
+	for i := 1; i < len(order); i++ {
+		u, v := order[i-1], order[i]
+		edges = append(edges, [2]int{min(u, v), max(u, v)})
+	}
+	return edges
+}
+
+// planarGraph строит случайный планарный граф: триангуляция Делоне numNodes
+// случайных точек единичного квадрата, из которой каждое ребро удаляется
+// с вероятностью deleteProb. Без треугольников точки соединяются путём linePath.
+// Вес ребра — его длина.
+// Триангуляция строится за O(n²), поэтому модель рассчитана на графы
+// примерно до 10⁴ узлов: 2·10⁴ узлов строятся уже около 20 секунд.
+func planarGraph(seed int64, numNodes int, deleteProb float64) ([]point, map[int][]int, map[[2]int]float64, error) {
+	if numNodes < 0 {
+		return nil, nil, nil, fmt.Errorf("число узлов не может быть отрицательным: %d", numNodes)
+	}
+	if deleteProb < 0 || deleteProb > 1 {
+		return nil, nil, nil, fmt.Errorf("вероятность удаления ребра должна лежать в [0, 1]: %g", deleteProb)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	points := make([]point, numNodes)
+	for i := range points {
+		points[i] = point{X: rng.Float64(), Y: rng.Float64()}
+	}
+
+	var edges [][2]int
+	seen := make(map[[2]int]bool)
+	triangles := delaunayTriangles(points)
+	if len(triangles) == 0 {
+		edges = linePath(points)
+	}
+	for _, tr := range triangles {
+		for i := 0; i < 3; i++ {
+			u, v := tr[i], tr[(i+1)%3]
+			key := [2]int{min(u, v), max(u, v)}
+			if !seen[key] {
+				seen[key] = true
+				edges = append(edges, key)
+			}
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i][0] != edges[j][0] {
+			return edges[i][0] < edges[j][0]
+		}
+		return edges[i][1] < edges[j][1]
+	})
+
+	graph := emptyGraph(numNodes)
+	weights := make(map[[2]int]float64, len(edges))
+	for _, e := range edges {
+		if rng.Float64() < deleteProb {
+			continue
+		}
+		addUndirected(graph, e[0], e[1])
+		weights[e] = distance(points[e[0]], points[e[1]], false)
+	}
+	return points, graph, weights, nil
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// pruferDecode восстанавливает дерево на узлах 0..len(seq)+1 по последовательности Прюфера
+// за O(n). Возвращает рёбра дерева.
+func pruferDecode(seq []int) ([][2]int, error) {
+	n := len(seq) + 2
+	degree := make([]int, n)
+	for i := range degree {
+		degree[i] = 1
+	}
+	for _, v := range seq {
+		if v < 0 || v >= n {
+			return nil, fmt.Errorf("элемент последовательности %d вне [0, %d)", v, n)
+		}
+		degree[v]++
+	}
+
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+
+	edges := make([][2]int, 0, n-1)
+	for _, v := range seq {
+		edges = append(edges, [2]int{leaf, v})
+		degree[v]--
+		if degree[v] == 1 && v < ptr {
+			leaf = v
+		} else {
+			ptr++
+			for degree[ptr] != 1 {
+				ptr++
+			}
+			leaf = ptr
+		}
+	}
+	edges = append(edges, [2]int{leaf, n - 1})
+	return edges, nil
+}
+
+// pruferEncode строит последовательность Прюфера дерева на узлах 0..numNodes-1 за O(n).
+// Если граф не является деревом, возвращается ошибка.
+func pruferEncode(graph map[int][]int, numNodes int) ([]int, error) {
+	if numNodes < 2 {
+		return nil, fmt.Errorf("последовательность Прюфера определена для деревьев из двух и более узлов, получено %d", numNodes)
+	}
+
+	edgeCount := 0
+	arcs := make(map[[2]int]bool)
+	for u, neighbors := range graph {
+		if u < 0 || u >= numNodes {
+			return nil, fmt.Errorf("узел %d вне [0, %d)", u, numNodes)
+		}
+		for _, v := range neighbors {
+			switch {
+			case v < 0 || v >= numNodes:
+				return nil, fmt.Errorf("сосед %d узла %d вне [0, %d)", v, u, numNodes)
+			case v == u:
+				return nil, fmt.Errorf("петля в узле %d", u)
+			case arcs[[2]int{u, v}]:
+				return nil, fmt.Errorf("ребро %d-%d записано дважды", u, v)
+			}
+			arcs[[2]int{u, v}] = true
+		}
+		edgeCount += len(neighbors)
+	}
+	// Каждое ребро должно быть записано у обоих концов, иначе степени узлов
+	// не согласуются с деревом обхода
+	for arc := range arcs {
+		if !arcs[[2]int{arc[1], arc[0]}] {
+			return nil, fmt.Errorf("ребро %d-%d есть в списке узла %d, но не узла %d", arc[0], arc[1], arc[0], arc[1])
+		}
+	}
+	if edgeCount != 2*(numNodes-1) {
+		return nil, fmt.Errorf("в дереве из %d узлов должно быть %d рёбер, а их %d", numNodes, numNodes-1, edgeCount/2)
+	}
+
+	// Подвешиваем дерево за последний узел и заодно проверяем связность
+	parent := make([]int, numNodes)
+	visited := make([]bool, numNodes)
+	visited[numNodes-1] = true
+	parent[numNodes-1] = -1
+	stack := []int{numNodes - 1}
+	reached := 1
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, v := range graph[u] {
+			if !visited[v] {
+				visited[v] = true
+				parent[v] = u
+				reached++
+				stack = append(stack, v)
+			}
+		}
+	}
+	if reached != numNodes {
+		return nil, fmt.Errorf("граф несвязен: из узла %d достижимо %d узлов из %d", numNodes-1, reached, numNodes)
+	}
+
+	degree := make([]int, numNodes)
+	for u := 0; u < numNodes; u++ {
+		degree[u] = len(graph[u])
+	}
+	ptr := 0
+	for degree[ptr] != 1 {
+		ptr++
+	}
+	leaf := ptr
+
+	seq := make([]int, 0, numNodes-2)
+	for i := 0; i < numNodes-2; i++ {
+		next := parent[leaf]
+		seq = append(seq, next)
+		degree[next]--
+		if degree[next] == 1 && next < ptr {
+			leaf = next
+		} else {
+			ptr++
+			for degree[ptr] != 1 {
+				ptr++
+			}
+			leaf = ptr
+		}
+	}
+	return seq, nil
+}
+
+// pruferTree строит равномерно случайное помеченное дерево на numNodes узлах
+// по случайной последовательности Прюфера; веса рёбер случайны в [minWeight, maxWeight]
+func pruferTree(seed int64, numNodes, minWeight, maxWeight int) (map[int][]int, map[[2]int]int, error) {
+	if numNodes < 1 {
+		return nil, nil, fmt.Errorf("нужен хотя бы один узел, получено %d", numNodes)
+	}
+	if err := checkWeightRange(minWeight, maxWeight); err != nil {
+		return nil, nil, err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	graph := map[int][]int{0: nil}
+	if numNodes == 1 {
+		return graph, map[[2]int]int{}, nil
+	}
+
+	seq := make([]int, numNodes-2)
+	for i := range seq {
+		seq[i] = rng.Intn(numNodes)
+	}
+	edges, err := pruferDecode(seq)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, e := range edges {
+		graph[e[0]] = append(graph[e[0]], e[1])
+		graph[e[1]] = append(graph[e[1]], e[0])
+	}
+	return graph, assignWeights(rng, graph, false, minWeight, maxWeight), nil
+}
+
+// plantedTree — случайное дерево pruferTree с noiseEdges «шумовыми» рёбрами.
+// Вес любого шумового ребра больше веса любого ребра дерева, поэтому
+// минимальное остовное дерево графа совпадает с посаженным деревом.
+// Возвращает граф, веса и рёбра посаженного дерева.
+func plantedTree(seed int64, numNodes, noiseEdges, minWeight, maxWeight int) (map[int][]int, map[[2]int]int, [][2]int, error) {
+	graph, weights, err := pruferTree(seed, numNodes, minWeight, maxWeight)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	maxNoise := numNodes*(numNodes-1)/2 - (numNodes - 1)
+	if noiseEdges < 0 || noiseEdges > maxNoise {
+		return nil, nil, nil, fmt.Errorf("шумовых рёбер может быть от 0 до %d, запрошено %d", maxNoise, noiseEdges)
+	}
+
+	tree := make([][2]int, 0, len(weights))
+	for _, u := range sortedNodes(graph) {
+		for _, v := range graph[u] {
+			if u < v {
+				tree = append(tree, [2]int{u, v})
+			}
+		}
+	}
+
+	// Отдельный генератор, чтобы шум не менял само дерево при том же зерне
+	rng := rand.New(rand.NewSource(seed + 1))
+	for added := 0; added < noiseEdges; {
+		u, v := rng.Intn(numNodes), rng.Intn(numNodes)
+		key := [2]int{min(u, v), max(u, v)}
+		if u == v {
+			continue
+		}
+		if _, exists := weights[key]; exists {
+			continue
+		}
+		graph[u] = append(graph[u], v)
+		graph[v] = append(graph[v], u)
+		weights[key] = randomWeight(rng, maxWeight+1, 2*maxWeight-minWeight+1)
+		added++
+	}
+	return graph, weights, tree, nil
+}
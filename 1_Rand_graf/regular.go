@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// maxRegularAttempts — сколько раз пробуем собрать паросочетание заново, прежде чем сдаться
+const maxRegularAttempts = 1000
+
+// randomRegular строит случайный простой d-регулярный граф на numNodes узлах
+// моделью конфигураций: у каждого узла d «полурёбер», которые случайно
+// разбиваются на пары. Пары, дающие петлю или кратное ребро, не отбрасываются
+// целиком, а перемешиваются и разбиваются заново (как у Стегера–Уормальда);
+// если подходящих пар не осталось, попытка начинается сначала.
+func randomRegular(seed int64, numNodes, d int) (map[int][]int, error) {
+	if numNodes < 0 || d < 0 {
+		return nil, fmt.Errorf("число узлов и степень не могут быть отрицательными: n=%d, d=%d", numNodes, d)
+	}
+	if numNodes*d%2 != 0 {
+		return nil, fmt.Errorf("d-регулярного графа не существует: n·d = %d·%d нечётно", numNodes, d)
+	}
+	if d >= numNodes && numNodes > 0 {
+		return nil, fmt.Errorf("степень d=%d должна быть меньше числа узлов %d", d, numNodes)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for attempt := 0; attempt < maxRegularAttempts; attempt++ {
+		if edges, ok := tryRegular(rng, numNodes, d); ok {
+			return edgesToGraph(edges, numNodes), nil
+		}
+	}
+	return nil, fmt.Errorf("не удалось построить %d-регулярный граф на %d узлах за %d попыток", d, numNodes, maxRegularAttempts)
+}
+
+func tryRegular(rng *rand.Rand, numNodes, d int) (map[[2]int]bool, bool) {
+	edges := make(map[[2]int]bool, numNodes*d/2)
+	stubs := make([]int, 0, numNodes*d)
+	for u := 0; u < numNodes; u++ {
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, u)
+		}
+	}
+
+	for len(stubs) > 0 {
+		rng.Shuffle(len(stubs), func(i, j int) { stubs[i], stubs[j] = stubs[j], stubs[i] })
+		var rest []int
+		for i := 0; i+1 < len(stubs); i += 2 {
+			u, v := min(stubs[i], stubs[i+1]), max(stubs[i], stubs[i+1])
+			if u != v && !edges[[2]int{u, v}] {
+				edges[[2]int{u, v}] = true
+			} else {
+				rest = append(rest, u, v)
+			}
+		}
+		sort.Ints(rest)
+		if !hasSuitablePair(rest, rest, edges) {
+			return nil, false
+		}
+		stubs = rest
+	}
+	return edges, true
+}
+
+// randomRegularBipartite строит случайный простой d-регулярный двудольный граф:
+// доли 0..n-1 и n..2n-1, у каждого узла ровно d соседей в другой доле.
+func randomRegularBipartite(seed int64, numPerSide, d int) (map[int][]int, error) {
+	if numPerSide < 0 || d < 0 {
+		return nil, fmt.Errorf("число узлов и степень не могут быть отрицательными: n=%d, d=%d", numPerSide, d)
+	}
+	if d > numPerSide {
+		return nil, fmt.Errorf("степень d=%d больше размера доли %d", d, numPerSide)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	for attempt := 0; attempt < maxRegularAttempts; attempt++ {
+		if edges, ok := tryRegularBipartite(rng, numPerSide, d); ok {
+			return edgesToGraph(edges, 2*numPerSide), nil
+		}
+	}
+	return nil, fmt.Errorf("не удалось построить %d-регулярный двудольный граф с долями по %d узлов за %d попыток", d, numPerSide, maxRegularAttempts)
+}
+
+func tryRegularBipartite(rng *rand.Rand, numPerSide, d int) (map[[2]int]bool, bool) {
+	edges := make(map[[2]int]bool, numPerSide*d)
+	left := make([]int, 0, numPerSide*d)
+	right := make([]int, 0, numPerSide*d)
+	for u := 0; u < numPerSide; u++ {
+		for i := 0; i < d; i++ {
+			left = append(left, u)
+			right = append(right, numPerSide+u)
+		}
+	}
+
+	for len(left) > 0 {
+		rng.Shuffle(len(right), func(i, j int) { right[i], right[j] = right[j], right[i] })
+		var restLeft, restRight []int
+		for i := range left {
+			u, v := left[i], right[i]
+			if !edges[[2]int{u, v}] {
+				edges[[2]int{u, v}] = true
+			} else {
+				restLeft = append(restLeft, u)
+				restRight = append(restRight, v)
+			}
+		}
+		sort.Ints(restLeft)
+		sort.Ints(restRight)
+		if !hasSuitablePair(restLeft, restRight, edges) {
+			return nil, false
+		}
+		left, right = restLeft, restRight
+	}
+	return edges, true
+}
+
+// hasSuitablePair проверяет, что среди оставшихся полурёбер есть пара u из as и v из bs,
+// которая даёт новое ребро без петли. Пустые списки считаются подходящими.
+func hasSuitablePair(as, bs []int, edges map[[2]int]bool) bool {
+	if len(as) == 0 {
+		return true
+	}
+	for _, a := range as {
+		for _, b := range bs {
+			u, v := min(a, b), max(a, b)
+			if u != v && !edges[[2]int{u, v}] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// edgesToGraph собирает список смежности неориентированного графа на узлах 0..numNodes-1
+func edgesToGraph(edges map[[2]int]bool, numNodes int) map[int][]int {
+	graph := make(map[int][]int, numNodes)
+	for u := 0; u < numNodes; u++ {
+		graph[u] = nil
+	}
+	for edge := range edges {
+		graph[edge[0]] = append(graph[edge[0]], edge[1])
+		graph[edge[1]] = append(graph[edge[1]], edge[0])
+	}
+	for u := range graph {
+		sort.Ints(graph[u])
+	}
+	return graph
+}
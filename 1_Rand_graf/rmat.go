@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+// rmatChunk — число рёбер, которые порождаются одним генератором случайных чисел.
+// Разбиение на куски не зависит от числа горутин, поэтому результат
+// при одном и том же зерне одинаков на любой машине.
+const rmatChunk = 1 << 16
+
+// weightedEdge — взвешенная дуга
+type weightedEdge struct {
+	From, To, Weight int
+}
+
+// checkRmatParams проверяет параметры модели R-MAT
+func checkRmatParams(scale, edgeFactor int, a, b, c float64) error {
+	if scale < 0 || scale > 30 {
+		return fmt.Errorf("масштаб должен лежать в [0, 30]: %d", scale)
+	}
+	if edgeFactor < 0 {
+		return fmt.Errorf("число рёбер на вершину не может быть отрицательным: %d", edgeFactor)
+	}
+	d := 1 - a - b - c
+	if a < 0 || b < 0 || c < 0 || d < -1e-9 {
+		return fmt.Errorf("вероятности a=%g, b=%g, c=%g должны быть неотрицательны и в сумме не больше 1", a, b, c)
+	}
+	return nil
+}
+
+// rmatEdges порождает граф R-MAT (рекурсивная матрица, как в Graph500):
+// 2^scale вершин и edgeFactor·2^scale дуг. Для каждой дуги scale раз выбирается
+// четверть матрицы смежности с вероятностями a, b, c и d = 1-a-b-c.
+// Петли и кратные дуги сохраняются, как в Graph500. Номера вершин в конце
+// перемешиваются биекцией rmatScramble, чтобы вершины большой степени не шли подряд.
+// Куски рёбер порождаются параллельно в workers горутинах, но передаются в emit
+// строго по порядку; в памяти одновременно не больше workers+1 кусков.
+func rmatEdges(seed int64, scale, edgeFactor int, a, b, c float64, minWeight, maxWeight, workers int, emit func(e weightedEdge) error) error {
+	if err := checkRmatParams(scale, edgeFactor, a, b, c); err != nil {
+		return err
+	}
+	if err := checkWeightRange(minWeight, maxWeight); err != nil {
+		return err
+	}
+	workers = max(workers, 1)
+
+	numEdges := edgeFactor << scale
+	numChunks := (numEdges + rmatChunk - 1) / rmatChunk
+	keys := rmatScrambleKeys(seed)
+	generate := func(chunk int) []weightedEdge {
+		rng := rand.New(rand.NewSource(chunkSeed(seed, chunk)))
+		edges := make([]weightedEdge, min(rmatChunk, numEdges-chunk*rmatChunk))
+		for i := range edges {
+			from, to := 0, 0
+			for bit := 0; bit < scale; bit++ {
+				r := rng.Float64()
+				switch {
+				case r < a:
+				case r < a+b:
+					to |= 1 << bit
+				case r < a+b+c:
+					from |= 1 << bit
+				default:
+					from |= 1 << bit
+					to |= 1 << bit
+				}
+			}
+			edges[i] = weightedEdge{
+				From:   rmatScramble(from, scale, keys),
+				To:     rmatScramble(to, scale, keys),
+				Weight: randomWeight(rng, minWeight, maxWeight),
+			}
+		}
+		return edges
+	}
+
+	// Канал pending хранит результаты кусков в порядке номеров; его ёмкость
+	// ограничивает, на сколько кусков генерация может обогнать запись
+	pending := make(chan chan []weightedEdge, workers)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(pending)
+		for chunk := 0; chunk < numChunks; chunk++ {
+			result := make(chan []weightedEdge, 1)
+			select {
+			case pending <- result:
+			case <-done:
+				return
+			}
+			go func() { result <- generate(chunk) }()
+		}
+	}()
+
+	for result := range pending {
+		for _, e := range <-result {
+			if err := emit(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rmatScrambleKeys выводит из зерна ключи перемешивания номеров вершин
+func rmatScrambleKeys(seed int64) [3]uint64 {
+	var keys [3]uint64
+	for i := range keys {
+		keys[i] = uint64(chunkSeed(seed, -2-i))
+	}
+	return keys
+}
+
+// rmatScramble — биекция на [0, 2^scale), заменяющая случайную перестановку
+// вершин: таблица перестановки при scale = 30 заняла бы гигабайты.
+// Каждый раунд — сдвиг, умножение на нечётное число и xor со сдвигом вправо;
+// все три операции обратимы по модулю 2^scale.
+func rmatScramble(vertex, scale int, keys [3]uint64) int {
+	mask := uint64(1)<<scale - 1
+	x := uint64(vertex)
+	for _, key := range keys {
+		x = (x + key) & mask
+		x = (x * (key | 1)) & mask
+		x ^= x >> (scale/2 + 1)
+	}
+	return int(x)
+}
+
+// chunkSeed выводит зерно куска из общего зерна (перемешивание splitmix64)
+func chunkSeed(seed int64, chunk int) int64 {
+	z := uint64(seed) + uint64(chunk+1)*0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return int64((z ^ (z >> 31)) & math.MaxInt64)
+}
+
+// saveEdgeListDijkstraCSV пишет список дуг в формате Graph.SaveToCSV из 7_Dijkstra.
+// Дуги передаёт generate по одной, и в памяти они не хранятся; возвращается их число.
+func saveEdgeListDijkstraCSV(numNodes int, generate func(emit func(e weightedEdge) error) error, filename, header string) (int, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	if header != "" {
+		if _, err := fmt.Fprintln(buffered, header); err != nil {
+			return 0, err
+		}
+	}
+
+	writer := csv.NewWriter(buffered)
+	if err := writer.Write([]string{strconv.Itoa(numNodes)}); err != nil {
+		return 0, err
+	}
+	numEdges := 0
+	err = generate(func(e weightedEdge) error {
+		numEdges++
+		return writer.Write([]string{strconv.Itoa(e.From), strconv.Itoa(e.To), strconv.Itoa(e.Weight)})
+	})
+	if err != nil {
+		return numEdges, err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return numEdges, err
+	}
+	return numEdges, buffered.Flush()
+}
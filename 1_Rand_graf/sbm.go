@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// stochasticBlockModel строит граф стохастической блочной модели: узлы разбиты
+// на блоки размеров blockSizes (узлы нумеруются подряд, блок за блоком), и пара
+// узлов из блоков a и b соединяется с вероятностью probs[a][b].
+// Возвращает граф и номер блока каждого узла — «посаженные» сообщества.
+// Многодольный граф GenerateKPartiteGraph из 9_Color-graph — частный случай
+// с нулевой диагональю probs.
+func stochasticBlockModel(seed int64, blockSizes []int, probs [][]float64) (map[int][]int, []int, error) {
+	if len(probs) != len(blockSizes) {
+		return nil, nil, fmt.Errorf("матрица вероятностей %d×?, а блоков %d", len(probs), len(blockSizes))
+	}
+	for a := range probs {
+		if len(probs[a]) != len(blockSizes) {
+			return nil, nil, fmt.Errorf("строка %d матрицы вероятностей имеет длину %d, ожидалось %d", a, len(probs[a]), len(blockSizes))
+		}
+		for b := range probs[a] {
+			if probs[a][b] < 0 || probs[a][b] > 1 {
+				return nil, nil, fmt.Errorf("вероятность probs[%d][%d]=%g вне [0, 1]", a, b, probs[a][b])
+			}
+			if probs[a][b] != probs[b][a] {
+				return nil, nil, fmt.Errorf("матрица вероятностей несимметрична: probs[%d][%d] != probs[%d][%d]", a, b, b, a)
+			}
+		}
+	}
+
+	var labels []int
+	for block, size := range blockSizes {
+		if size < 0 {
+			return nil, nil, fmt.Errorf("размер блока %d отрицателен: %d", block, size)
+		}
+		for i := 0; i < size; i++ {
+			labels = append(labels, block)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	graph := make(map[int][]int, len(labels))
+	for u := range labels {
+		graph[u] = nil
+	}
+	for u := range labels {
+		for v := u + 1; v < len(labels); v++ {
+			if rng.Float64() < probs[labels[u]][labels[v]] {
+				graph[u] = append(graph[u], v)
+				graph[v] = append(graph[v], u)
+			}
+		}
+	}
+	return graph, labels, nil
+}
+
+// parseProbMatrix разбирает матрицу вероятностей вида "0.5,0.1;0.1,0.5": строки через «;»
+func parseProbMatrix(s string) ([][]float64, error) {
+	rows := strings.Split(s, ";")
+	probs := make([][]float64, len(rows))
+	for i, row := range rows {
+		for _, field := range strings.Split(row, ",") {
+			p, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("неверная вероятность %q", field)
+			}
+			probs[i] = append(probs[i], p)
+		}
+	}
+	return probs, nil
+}
+
+// saveLabelsCSV сохраняет номера блоков узлов строками "узел,блок"
+func saveLabelsCSV(labels []int, filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	for node, label := range labels {
+		if err := writer.Write([]string{strconv.Itoa(node), strconv.Itoa(label)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
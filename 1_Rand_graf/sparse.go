@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+)
+
+// sparseGnp порождает рёбра графа G(n,p) методом геометрических пропусков
+// (Батагель–Брандес): вместо броска монетки для каждой пары длина пропуска
+// до следующего ребра выбирается из геометрического распределения.
+// Распределение графов то же, что у gRGraph, но время O(n+m).
+// Рёбра (u,v), u > v, передаются в emit по мере генерации и нигде не хранятся.
+func sparseGnp(seed int64, numNodes int, connectivity float64, emit func(u, v int) error) error {
+	if numNodes < 0 {
+		return fmt.Errorf("число узлов не может быть отрицательным: %d", numNodes)
+	}
+	if connectivity < 0 || connectivity > 1 {
+		return fmt.Errorf("вероятность ребра должна лежать в [0, 1]: %g", connectivity)
+	}
+	if connectivity == 0 {
+		return nil
+	}
+	if connectivity == 1 {
+		for u := 1; u < numNodes; u++ {
+			for v := 0; v < u; v++ {
+				if err := emit(u, v); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	// Log1p не обращается в 0 при крошечной вероятности, в отличие от Log(1-p)
+	logQ := math.Log1p(-connectivity)
+	pairs := numNodes * (numNodes - 1) / 2
+	u, v := 1, -1
+	for u < numNodes {
+		// 1-Float64() лежит в (0, 1], поэтому логарифм конечен, но частное
+		// может быть огромным: пропуск сравнивается с числом оставшихся пар
+		// до перевода в int
+		skip := math.Floor(math.Log(1-rng.Float64()) / logQ)
+		remaining := pairs - (u*(u-1)/2 + v + 1)
+		if skip >= float64(remaining) {
+			return nil
+		}
+		v += 1 + int(skip)
+		for v >= u && u < numNodes {
+			v -= u
+			u++
+		}
+		if u < numNodes {
+			if err := emit(u, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// saveSparseGnpCSV пишет граф G(n,p) списком рёбер "u,v" прямо в файл, не собирая его в памяти
+func saveSparseGnpCSV(seed int64, numNodes int, connectivity float64, filename, header string) (int, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	buffered := bufio.NewWriter(file)
+	if header != "" {
+		if _, err := fmt.Fprintln(buffered, header); err != nil {
+			return 0, err
+		}
+	}
+
+	writer := csv.NewWriter(buffered)
+	numEdges := 0
+	err = sparseGnp(seed, numNodes, connectivity, func(u, v int) error {
+		numEdges++
+		return writer.Write([]string{strconv.Itoa(u), strconv.Itoa(v)})
+	})
+	if err != nil {
+		return numEdges, err
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return numEdges, err
+	}
+	return numEdges, buffered.Flush()
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+)
+
+// exactDiameterWork — предел произведения числа вершин на число рёбер наибольшей
+// компоненты, до которого диаметр считается точно BFS из каждой вершины
+// (время O(n·m)); для больших компонент берётся оценка снизу методом двойного прохода
+const exactDiameterWork = 1 << 27
+
+// graphStats — сводка о сгенерированном графе. Компоненты, треугольники,
+// коэффициент кластеризации и диаметр считаются по неориентированному
+// графу без учёта направлений (для орграфа компоненты — слабые).
+type graphStats struct {
+	Nodes           int         `json:"nodes"`
+	Edges           int         `json:"edges"`
+	Directed        bool        `json:"directed"`
+	MinDegree       int         `json:"min_degree"`
+	MaxDegree       int         `json:"max_degree"`
+	AvgDegree       float64     `json:"avg_degree"`
+	DegreeHistogram map[int]int `json:"degree_histogram"`
+	Components      int         `json:"components"`
+	ComponentSizes  []int       `json:"component_sizes"`
+	Triangles       int         `json:"triangles"`
+	AvgClustering   float64     `json:"avg_clustering"`
+	Diameter        int         `json:"diameter"`
+	DiameterIsExact bool        `json:"diameter_is_exact"`
+}
+
+// computeStats собирает статистику графа на вершинах 0..n-1.
+// Степень вершины в орграфе — сумма входящей и исходящей.
+func computeStats(graph map[int][]int, n int, directed bool) graphStats {
+	stats := graphStats{Nodes: n, Directed: directed, DegreeHistogram: make(map[int]int)}
+
+	// Списки соседей без петель и кратных рёбер, по возрастанию
+	degree := make([]int, n)
+	neighbors := make([][]int, n)
+	arcs := 0
+	for u, list := range graph {
+		for _, v := range list {
+			arcs++
+			degree[u]++
+			if directed {
+				degree[v]++
+			}
+			if u != v {
+				neighbors[u] = append(neighbors[u], v)
+				neighbors[v] = append(neighbors[v], u)
+			}
+		}
+	}
+	for u, list := range neighbors {
+		sort.Ints(list)
+		neighbors[u] = slices.Compact(list)
+	}
+	stats.Edges = arcs
+	if !directed {
+		stats.Edges /= 2
+	}
+
+	if n > 0 {
+		stats.MinDegree = degree[0]
+		total := 0
+		for _, d := range degree {
+			stats.DegreeHistogram[d]++
+			stats.MinDegree = min(stats.MinDegree, d)
+			stats.MaxDegree = max(stats.MaxDegree, d)
+			total += d
+		}
+		stats.AvgDegree = float64(total) / float64(n)
+	}
+
+	// Треугольник u<v<w считается один раз: общие соседи w > v находятся
+	// слиянием хвостов отсортированных списков u и v; попутно копим
+	// треугольники при каждой вершине
+	trianglesAt := make([]int, n)
+	for u := 0; u < n; u++ {
+		for i, v := range neighbors[u] {
+			if v <= u {
+				continue
+			}
+			a := neighbors[u][i+1:]
+			b := neighbors[v][sort.SearchInts(neighbors[v], v+1):]
+			for len(a) > 0 && len(b) > 0 {
+				switch {
+				case a[0] < b[0]:
+					a = a[1:]
+				case a[0] > b[0]:
+					b = b[1:]
+				default:
+					stats.Triangles++
+					trianglesAt[u]++
+					trianglesAt[v]++
+					trianglesAt[a[0]]++
+					a, b = a[1:], b[1:]
+				}
+			}
+		}
+	}
+	if n > 0 {
+		sum := 0.0
+		for u := 0; u < n; u++ {
+			if d := len(neighbors[u]); d >= 2 {
+				sum += 2 * float64(trianglesAt[u]) / float64(d*(d-1))
+			}
+		}
+		stats.AvgClustering = sum / float64(n)
+	}
+
+	component := make([]int, n)
+	for u := range component {
+		component[u] = -1
+	}
+	var largest []int
+	largestEdges := 0
+	for start := 0; start < n; start++ {
+		if component[start] != -1 {
+			continue
+		}
+		members := []int{start}
+		component[start] = stats.Components
+		ends := 0
+		for i := 0; i < len(members); i++ {
+			ends += len(neighbors[members[i]])
+			for _, v := range neighbors[members[i]] {
+				if component[v] == -1 {
+					component[v] = stats.Components
+					members = append(members, v)
+				}
+			}
+		}
+		stats.ComponentSizes = append(stats.ComponentSizes, len(members))
+		if len(members) > len(largest) {
+			largest, largestEdges = members, ends/2
+		}
+		stats.Components++
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(stats.ComponentSizes)))
+
+	// Диаметр меряем в самой большой компоненте; массив расстояний общий для всех BFS
+	if len(largest) > 0 {
+		dist := make([]int, n)
+		for u := range dist {
+			dist[u] = -1
+		}
+		queue := make([]int, 0, len(largest))
+		if len(largest)*largestEdges <= exactDiameterWork {
+			for _, u := range largest {
+				_, ecc := farthest(neighbors, u, dist, queue)
+				stats.Diameter = max(stats.Diameter, ecc)
+			}
+			stats.DiameterIsExact = true
+		} else {
+			// Двойной проход: BFS из самой удалённой найденной вершины, несколько раз
+			u := largest[0]
+			for sweep := 0; sweep < 4; sweep++ {
+				next, ecc := farthest(neighbors, u, dist, queue)
+				stats.Diameter = max(stats.Diameter, ecc)
+				u = next
+			}
+		}
+	}
+	return stats
+}
+
+// farthest возвращает самую удалённую от start вершину и расстояние до неё.
+// dist должен быть заполнен -1 и после обхода снова заполняется -1,
+// queue — буфер очереди; так повторные BFS не выделяют память.
+func farthest(neighbors [][]int, start int, dist, queue []int) (int, int) {
+	queue = append(queue[:0], start)
+	dist[start] = 0
+	far := start
+	for i := 0; i < len(queue); i++ {
+		u := queue[i]
+		if dist[u] > dist[far] {
+			far = u
+		}
+		for _, v := range neighbors[u] {
+			if dist[v] == -1 {
+				dist[v] = dist[u] + 1
+				queue = append(queue, v)
+			}
+		}
+	}
+	ecc := dist[far]
+	for _, u := range queue {
+		dist[u] = -1
+	}
+	return far, ecc
+}
+
+// writeStats печатает статистику в формате "text" или "json"
+func writeStats(w io.Writer, stats graphStats, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case "text":
+		kind := "неориентированный"
+		if stats.Directed {
+			kind = "ориентированный"
+		}
+		diameter := "оценка снизу"
+		if stats.DiameterIsExact {
+			diameter = "точно"
+		}
+		fmt.Fprintf(w, "граф: %s, вершин %d, рёбер %d\n", kind, stats.Nodes, stats.Edges)
+		fmt.Fprintf(w, "степени: мин %d, макс %d, средняя %.3f\n", stats.MinDegree, stats.MaxDegree, stats.AvgDegree)
+		fmt.Fprintln(w, "гистограмма степеней:")
+		degrees := make([]int, 0, len(stats.DegreeHistogram))
+		for d := range stats.DegreeHistogram {
+			degrees = append(degrees, d)
+		}
+		sort.Ints(degrees)
+		for _, d := range degrees {
+			fmt.Fprintf(w, "  %d: %d\n", d, stats.DegreeHistogram[d])
+		}
+		fmt.Fprintf(w, "компонент связности: %d, размеры: %v\n", stats.Components, stats.ComponentSizes)
+		fmt.Fprintf(w, "треугольников: %d\n", stats.Triangles)
+		fmt.Fprintf(w, "средний коэффициент кластеризации: %.4f\n", stats.AvgClustering)
+		_, err := fmt.Fprintf(w, "диаметр наибольшей компоненты: %d (%s)\n", stats.Diameter, diameter)
+		return err
+	}
+	return fmt.Errorf("неизвестный формат отчёта: %s", format)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// wattsStrogatz строит граф «малого мира» модели Уоттса–Строгаца.
+// Узлы расставлены по кольцу, каждый соединён с k ближайшими (по k/2 с каждой стороны),
+// затем каждое ребро (u, u+j) с вероятностью beta перенаправляется
+// в случайный узел, не создавая петель и кратных рёбер.
+func wattsStrogatz(seed int64, numNodes, k int, beta float64) (map[int][]int, error) {
+	if k%2 != 0 || k < 2 {
+		return nil, fmt.Errorf("степень решётки k должна быть чётной и не меньше 2: %d", k)
+	}
+	if k >= numNodes {
+		return nil, fmt.Errorf("степень решётки k=%d должна быть меньше числа узлов %d", k, numNodes)
+	}
+	if beta < 0 || beta > 1 {
+		return nil, fmt.Errorf("вероятность перестройки должна лежать в [0, 1]: %g", beta)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	adjacency := make([]map[int]bool, numNodes)
+	for u := range adjacency {
+		adjacency[u] = make(map[int]bool, k)
+	}
+	for u := 0; u < numNodes; u++ {
+		for j := 1; j <= k/2; j++ {
+			v := (u + j) % numNodes
+			adjacency[u][v] = true
+			adjacency[v][u] = true
+		}
+	}
+
+	// Перестраиваем рёбра по кругам: сначала все рёбра к ближайшему соседу, затем ко второму и т. д.
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < numNodes; u++ {
+			v := (u + j) % numNodes
+			if !adjacency[u][v] || rng.Float64() >= beta {
+				continue
+			}
+			// Если u уже соединён со всеми, перестраивать некуда
+			if len(adjacency[u]) >= numNodes-1 {
+				continue
+			}
+			w := rng.Intn(numNodes)
+			for w == u || adjacency[u][w] {
+				w = rng.Intn(numNodes)
+			}
+			delete(adjacency[u], v)
+			delete(adjacency[v], u)
+			adjacency[u][w] = true
+			adjacency[w][u] = true
+		}
+	}
+
+	graph := make(map[int][]int, numNodes)
+	for u, neighbors := range adjacency {
+		list := make([]int, 0, len(neighbors))
+		for v := range neighbors {
+			list = append(list, v)
+		}
+		sort.Ints(list)
+		graph[u] = list
+	}
+	return graph, nil
+}
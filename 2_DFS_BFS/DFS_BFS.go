@@ -3,59 +3,130 @@ package main
 import (
 	"container/list"
 	"fmt"
+	"iter"
 	"time"
+
+	"pricl_algoritmi/internal/adjacency"
+	"pricl_algoritmi/internal/biconnected"
 )
 
-// Функция (DFS)
-func DFS(graph map[int][]int, start int) {
+// Traversal — результат обхода графа из одной вершины
+type Traversal struct {
+	Order  []int       // вершины в порядке посещения
+	Parent map[int]int // вершина, из которой пришли; у стартовой вершины записи нет
+	Depth  map[int]int // число рёбер от стартовой вершины по дереву обхода
+}
+
+// newTraversal собирает Traversal по обходу walk
+func newTraversal(walk func(yield func(node, parent int) bool)) Traversal {
+	result := Traversal{Parent: make(map[int]int), Depth: make(map[int]int)}
+	first := true
+	walk(func(node, parent int) bool {
+		result.Order = append(result.Order, node)
+		if first {
+			result.Depth[node] = 0
+			first = false
+		} else {
+			result.Parent[node] = parent
+			result.Depth[node] = result.Depth[parent] + 1
+		}
+		return true
+	})
+	return result
+}
+
+// dfsWalk обходит граф в глубину и передаёт yield каждую вершину вместе с родителем;
+// обход прекращается, как только yield вернёт false
+func dfsWalk(graph map[int][]int, start int, yield func(node, parent int) bool) {
 	stack := list.New()
 	visited := make(map[int]bool)
 
-	stack.PushBack(start)
+	stack.PushBack([2]int{start, start})
 
 	for stack.Len() > 0 {
 		element := stack.Back()
 		stack.Remove(element)
-		node := element.Value.(int)
+		entry := element.Value.([2]int)
+		node := entry[0]
 
 		if !visited[node] {
 			visited[node] = true
-			fmt.Printf("%d ", node)
+			if !yield(node, entry[1]) {
+				return
+			}
 
 			for _, neighbor := range graph[node] {
 				if !visited[neighbor] {
-					stack.PushBack(neighbor)
+					stack.PushBack([2]int{neighbor, node})
 				}
 			}
 		}
 	}
-
-	fmt.Println()
 }
 
-// Функция (BFS)
-func BFS(graph map[int][]int, start int) {
+// bfsWalk обходит граф в ширину и передаёт yield каждую вершину вместе с родителем;
+// обход прекращается, как только yield вернёт false
+func bfsWalk(graph map[int][]int, start int, yield func(node, parent int) bool) {
 	queue := list.New()
 	visited := make(map[int]bool)
 
-	queue.PushBack(start)
+	queue.PushBack([2]int{start, start})
 	visited[start] = true
 
 	for queue.Len() > 0 {
 		element := queue.Front()
 		queue.Remove(element)
-		node := element.Value.(int)
+		entry := element.Value.([2]int)
+		node := entry[0]
 
-		fmt.Printf("%d ", node)
+		if !yield(node, entry[1]) {
+			return
+		}
 
 		for _, neighbor := range graph[node] {
 			if !visited[neighbor] {
 				visited[neighbor] = true
-				queue.PushBack(neighbor)
+				queue.PushBack([2]int{neighbor, node})
 			}
 		}
 	}
+}
+
+// DFS обходит граф в глубину из start
+func DFS(graph map[int][]int, start int) Traversal {
+	return newTraversal(func(yield func(node, parent int) bool) {
+		dfsWalk(graph, start, yield)
+	})
+}
+
+// BFS обходит граф в ширину из start; Depth — кратчайшие расстояния в рёбрах
+func BFS(graph map[int][]int, start int) Traversal {
+	return newTraversal(func(yield func(node, parent int) bool) {
+		bfsWalk(graph, start, yield)
+	})
+}
+
+// DFSSeq возвращает вершины в порядке обхода в глубину. Обход идёт лениво
+// и останавливается, когда цикл range прерывается.
+func DFSSeq(graph map[int][]int, start int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		dfsWalk(graph, start, func(node, _ int) bool { return yield(node) })
+	}
+}
 
+// BFSSeq возвращает вершины в порядке обхода в ширину. Обход идёт лениво
+// и останавливается, когда цикл range прерывается.
+func BFSSeq(graph map[int][]int, start int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		bfsWalk(graph, start, func(node, _ int) bool { return yield(node) })
+	}
+}
+
+// printOrder печатает вершины через пробел
+func printOrder(order []int) {
+	for _, node := range order {
+		fmt.Printf("%d ", node)
+	}
 	fmt.Println()
 }
 
@@ -73,14 +144,90 @@ func main() {
 	// Время DFS
 	start := time.Now()
 	fmt.Println("DFS обход:")
-	DFS(graph, 1)
+	dfs := DFS(graph, 1)
 	elapsed := time.Since(start)
+	printOrder(dfs.Order)
 	fmt.Printf("Время выполнения DFS: %s\n", elapsed)
 
 	// Время BFS
 	start = time.Now()
 	fmt.Println("BFS обход:")
-	BFS(graph, 1)
+	bfs := BFS(graph, 1)
 	elapsed = time.Since(start)
+	printOrder(bfs.Order)
 	fmt.Printf("Время выполнения BFS: %s\n", elapsed)
+
+	fmt.Println("Глубина вершин в дереве BFS:")
+	for _, node := range bfs.Order {
+		if parent, ok := bfs.Parent[node]; ok {
+			fmt.Printf("%d: глубина %d, родитель %d\n", node, bfs.Depth[node], parent)
+		} else {
+			fmt.Printf("%d: глубина %d, стартовая вершина\n", node, bfs.Depth[node])
+		}
+	}
+
+	// Полный обход в глубину ориентированного графа с классификацией рёбер
+	digraph := map[int][]int{
+		0: {1, 2},
+		1: {2},
+		2: {0},
+		3: {2, 4},
+		4: {3},
+	}
+	fmt.Println("Классы рёбер ориентированного графа:")
+	forest := FullDFS(digraph, Visitor{
+		OnEdge: func(from, to int, kind EdgeKind) {
+			fmt.Printf("%d -> %d: %s\n", from, to, kind)
+		},
+	})
+	for _, node := range adjacency.SortedVertices(digraph) {
+		fmt.Printf("%d: [%d, %d]\n", node, forest.Discovery[node], forest.Finish[node])
+	}
+
+	// Топологическая сортировка: дерево из начала программы — DAG, digraph содержит циклы
+	for _, g := range []map[int][]int{graph, digraph} {
+		if order, err := TopoSortKahn(g); err != nil {
+			fmt.Println("Алгоритм Кана:", err)
+		} else {
+			fmt.Println("Алгоритм Кана:", order)
+		}
+		if order, err := TopoSortDFS(g); err != nil {
+			fmt.Println("Сортировка через DFS:", err)
+		} else {
+			fmt.Println("Сортировка через DFS:", order)
+		}
+	}
+
+	// Точки сочленения и мосты неориентированного графа: два цикла, соединённые мостом 2-3
+	network := map[int][]int{
+		0: {1, 2},
+		1: {0, 2},
+		2: {0, 1, 3},
+		3: {2, 4, 5},
+		4: {3, 5},
+		5: {3, 4, 6},
+		6: {5},
+	}
+	blocks := biconnected.Decompose(network)
+	fmt.Println("Точки сочленения:", blocks.ArticulationPoints)
+	fmt.Println("Мосты:", blocks.Bridges)
+	fmt.Println("Блоки:", blocks.Blocks)
+	fmt.Println("Дерево блоков и точек сочленения:", blocks.BlockCutTree())
+
+	// Обход в ширину сразу из нескольких источников
+	spt := MultiSourceBFS(network, []int{0, 6})
+	for _, node := range adjacency.SortedVertices(network) {
+		fmt.Printf("%d: расстояние %d от источника %d, путь %v\n", node, spt.Dist[node], spt.Source[node], spt.PathTo(node))
+	}
+	if err := spt.SaveToCSV("bfs_tree.csv"); err != nil {
+		fmt.Println("Ошибка при записи дерева кратчайших путей:", err)
+		return
+	}
+	fmt.Println("Дерево кратчайших путей записано в bfs_tree.csv")
+
+	// Встречный обход в ширину: для орграфа обратный обход идёт по обращённым дугам
+	path, explored := BidirectionalBFS(digraph, ReverseGraph(digraph), 4, 1)
+	fmt.Printf("Путь 4 -> 1 в орграфе: %v, раскрыто вершин: %d\n", path, explored)
+	path, explored = BidirectionalBFS(network, network, 0, 6)
+	fmt.Printf("Путь 0 -> 6 в неориентированном графе: %v, раскрыто вершин: %d\n", path, explored)
 }
@@ -1,9 +1,13 @@
 package main
 
 import (
-	"bytes"
+	"errors"
+	"math/rand"
 	"os"
+	"slices"
 	"testing"
+
+	"pricl_algoritmi/internal/adjacency"
 )
 
 func compareSlices(a, b []int) bool {
@@ -35,11 +39,7 @@ func TestDFS(t *testing.T) {
 	// Ожидаемый результат DFS обхода
 	expected := []int{1, 3, 6, 2, 5, 7, 4}
 
-	output := captureOutput(func() {
-		DFS(graph, 1)
-	})
-
-	result := parseOutput(output)
+	result := DFS(graph, 1).Order
 
 	if !compareSlices(result, expected) {
 		t.Errorf("DFS обход неверный. Ожидалось: %v, Получено: %v", expected, result)
@@ -62,47 +62,464 @@ func TestBFS(t *testing.T) {
 	// Ожидаемый результат BFS обхода
 	expected := []int{1, 2, 3, 4, 5, 6, 7}
 
-	output := captureOutput(func() {
-		BFS(graph, 1)
-	})
-
-	result := parseOutput(output)
+	result := BFS(graph, 1).Order
 
 	if !compareSlices(result, expected) {
 		t.Errorf("BFS обход неверный. Ожидалось: %v, Получено: %v", expected, result)
 	}
 }
 
-func captureOutput(f func()) string {
-	old := os.Stdout
-	r, w, _ := os.Pipe()
-	os.Stdout = w
+// TestTraversalZeroVertex проверяет обход графа, в котором есть вершина 0
+func TestTraversalZeroVertex(t *testing.T) {
+	graph := map[int][]int{
+		0: {1, 2},
+		1: {0, 3},
+		2: {0, 3},
+		3: {1, 2, 4},
+		4: {3},
+	}
+
+	bfs := BFS(graph, 0)
+	if expected := []int{0, 1, 2, 3, 4}; !compareSlices(bfs.Order, expected) {
+		t.Errorf("BFS обход неверный. Ожидалось: %v, Получено: %v", expected, bfs.Order)
+	}
+	expectedDepth := map[int]int{0: 0, 1: 1, 2: 1, 3: 2, 4: 3}
+	for node, depth := range expectedDepth {
+		if bfs.Depth[node] != depth {
+			t.Errorf("BFS: глубина вершины %d — ожидалось %d, получено %d", node, depth, bfs.Depth[node])
+		}
+	}
+	if _, ok := bfs.Parent[0]; ok {
+		t.Error("У стартовой вершины не должно быть родителя")
+	}
+	if bfs.Parent[3] != 1 || bfs.Parent[4] != 3 {
+		t.Errorf("Неверные родители в дереве BFS: %v", bfs.Parent)
+	}
+
+	dfs := DFS(graph, 0)
+	if expected := []int{0, 2, 3, 4, 1}; !compareSlices(dfs.Order, expected) {
+		t.Errorf("DFS обход неверный. Ожидалось: %v, Получено: %v", expected, dfs.Order)
+	}
+	// Каждая вершина, кроме стартовой, на единицу глубже своего родителя
+	for node, parent := range dfs.Parent {
+		if dfs.Depth[node] != dfs.Depth[parent]+1 {
+			t.Errorf("DFS: глубина вершины %d не согласована с родителем %d", node, parent)
+		}
+	}
+	if dfs.Parent[1] != 3 || dfs.Depth[1] != 3 {
+		t.Errorf("DFS: вершина 1 — ожидались родитель 3 и глубина 3, получено %d и %d", dfs.Parent[1], dfs.Depth[1])
+	}
+}
+
+// TestTraversalSeq проверяет, что итераторы совпадают с обходами и останавливаются досрочно
+func TestTraversalSeq(t *testing.T) {
+	graph := map[int][]int{
+		1: {2, 3},
+		2: {4, 5},
+		3: {6},
+		5: {7},
+	}
+
+	if seq := slices.Collect(DFSSeq(graph, 1)); !compareSlices(seq, DFS(graph, 1).Order) {
+		t.Errorf("DFSSeq отличается от DFS: %v", seq)
+	}
+	if seq := slices.Collect(BFSSeq(graph, 1)); !compareSlices(seq, BFS(graph, 1).Order) {
+		t.Errorf("BFSSeq отличается от BFS: %v", seq)
+	}
+
+	var visited []int
+	for node := range BFSSeq(graph, 1) {
+		visited = append(visited, node)
+		if node == 3 {
+			break
+		}
+	}
+	if expected := []int{1, 2, 3}; !compareSlices(visited, expected) {
+		t.Errorf("Обход не остановился досрочно. Ожидалось: %v, Получено: %v", expected, visited)
+	}
+
+	visited = nil
+	for node := range DFSSeq(graph, 1) {
+		if node == 6 {
+			break
+		}
+		visited = append(visited, node)
+	}
+	if expected := []int{1, 3}; !compareSlices(visited, expected) {
+		t.Errorf("Обход не остановился досрочно. Ожидалось: %v, Получено: %v", expected, visited)
+	}
+}
+
+// TestFullDFS проверяет моменты открытия и закрытия вершин и классы рёбер
+func TestFullDFS(t *testing.T) {
+	graph := map[int][]int{
+		0: {1, 2},
+		1: {2},
+		2: {0},
+		3: {2},
+	}
+
+	kinds := make(map[[2]int]EdgeKind)
+	var entered, exited []int
+	forest := FullDFS(graph, Visitor{
+		OnEnter: func(node, _ int) { entered = append(entered, node) },
+		OnExit:  func(node, _ int) { exited = append(exited, node) },
+		OnEdge:  func(from, to int, kind EdgeKind) { kinds[[2]int{from, to}] = kind },
+	})
+
+	expectedKinds := map[[2]int]EdgeKind{
+		{0, 1}: TreeEdge,
+		{1, 2}: TreeEdge,
+		{2, 0}: BackEdge,
+		{0, 2}: ForwardEdge,
+		{3, 2}: CrossEdge,
+	}
+	if len(kinds) != len(expectedKinds) {
+		t.Errorf("Ожидалось %d рёбер, классифицировано %d", len(expectedKinds), len(kinds))
+	}
+	for edge, kind := range expectedKinds {
+		if kinds[edge] != kind {
+			t.Errorf("Ребро %v: ожидалось %s, получено %s", edge, kind, kinds[edge])
+		}
+	}
+
+	expectedDiscovery := map[int]int{0: 1, 1: 2, 2: 3, 3: 7}
+	expectedFinish := map[int]int{0: 6, 1: 5, 2: 4, 3: 8}
+	for node := range expectedDiscovery {
+		if forest.Discovery[node] != expectedDiscovery[node] || forest.Finish[node] != expectedFinish[node] {
+			t.Errorf("Вершина %d: ожидалось [%d, %d], получено [%d, %d]", node,
+				expectedDiscovery[node], expectedFinish[node], forest.Discovery[node], forest.Finish[node])
+		}
+	}
+
+	if !compareSlices(forest.Roots, []int{0, 3}) {
+		t.Errorf("Неверные корни леса: %v", forest.Roots)
+	}
+	if forest.Parent[1] != 0 || forest.Parent[2] != 1 || len(forest.Parent) != 2 {
+		t.Errorf("Неверные родители в лесу: %v", forest.Parent)
+	}
+	if !compareSlices(entered, []int{0, 1, 2, 3}) || !compareSlices(exited, []int{2, 1, 0, 3}) {
+		t.Errorf("Неверный порядок событий: вход %v, выход %v", entered, exited)
+	}
+}
+
+// TestFullDFSParentheses проверяет вложенность интервалов на графе,
+// где у части вершин нет собственного списка смежности
+func TestFullDFSParentheses(t *testing.T) {
+	graph := map[int][]int{
+		5: {1, 6},
+		1: {2, 3},
+		2: {3},
+		3: {1, 7},
+		6: {7, 2},
+	}
+
+	forest := FullDFS(graph, Visitor{
+		OnEdge: func(from, to int, kind EdgeKind) {
+			if kind == BackEdge && (from != 3 || to != 1) {
+				t.Errorf("Лишнее обратное ребро %d -> %d", from, to)
+			}
+		},
+	})
+
+	vertices := adjacency.SortedVertices(graph)
+	if len(forest.Discovery) != len(vertices) || len(forest.Finish) != len(vertices) {
+		t.Fatalf("Посещены не все вершины: %v", forest.Discovery)
+	}
+	for _, u := range vertices {
+		for _, v := range vertices {
+			du, fu := forest.Discovery[u], forest.Finish[u]
+			dv, fv := forest.Discovery[v], forest.Finish[v]
+			if du < dv && dv < fu && fu < fv {
+				t.Errorf("Интервалы вершин %d и %d пересекаются: [%d, %d] и [%d, %d]", u, v, du, fu, dv, fv)
+			}
+		}
+	}
+	for node, parent := range forest.Parent {
+		if forest.Discovery[parent] >= forest.Discovery[node] || forest.Finish[node] >= forest.Finish[parent] {
+			t.Errorf("Вершина %d не вложена в родителя %d", node, parent)
+		}
+	}
+}
+
+// checkTopoOrder проверяет, что order содержит каждую вершину графа ровно один раз
+// и все дуги идут вперёд по порядку
+func checkTopoOrder(t *testing.T, graph map[int][]int, order []int) {
+	t.Helper()
+	position := make(map[int]int)
+	for i, node := range order {
+		if _, ok := position[node]; ok {
+			t.Fatalf("Вершина %d встречается в порядке дважды: %v", node, order)
+		}
+		position[node] = i
+	}
+	if len(order) != len(adjacency.SortedVertices(graph)) {
+		t.Fatalf("В порядке %d вершин вместо %d: %v", len(order), len(adjacency.SortedVertices(graph)), order)
+	}
+	for from, neighbors := range graph {
+		for _, to := range neighbors {
+			if position[from] >= position[to] {
+				t.Errorf("Дуга %d -> %d идёт назад в порядке %v", from, to, order)
+			}
+		}
+	}
+}
+
+// checkCycle проверяет, что err — *CycleError с настоящим циклом графа
+func checkCycle(t *testing.T, graph map[int][]int, err error) {
+	t.Helper()
+	var cycleErr *CycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("Ожидалась ошибка *CycleError, получено %v", err)
+	}
+	cycle := cycleErr.Cycle
+	if len(cycle) == 0 {
+		t.Fatal("Пустой цикл")
+	}
+	seen := make(map[int]bool)
+	for i, from := range cycle {
+		if seen[from] {
+			t.Errorf("Вершина %d повторяется в цикле %v", from, cycle)
+		}
+		seen[from] = true
+		to := cycle[(i+1)%len(cycle)]
+		if !slices.Contains(graph[from], to) {
+			t.Errorf("В цикле %v нет дуги %d -> %d", cycle, from, to)
+		}
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	dag := map[int][]int{
+		5: {0, 2},
+		4: {0, 1},
+		2: {3},
+		3: {1},
+		6: {},
+	}
+	for name, sortFunc := range map[string]func(map[int][]int) ([]int, error){
+		"Кан": TopoSortKahn,
+		"DFS": TopoSortDFS,
+	} {
+		order, err := sortFunc(dag)
+		if err != nil {
+			t.Fatalf("%s: неожиданная ошибка: %v", name, err)
+		}
+		checkTopoOrder(t, dag, order)
+
+		cyclic := map[int][]int{
+			0: {1},
+			1: {2, 4},
+			2: {3},
+			3: {1},
+			4: {5},
+		}
+		_, err = sortFunc(cyclic)
+		checkCycle(t, cyclic, err)
+
+		loop := map[int][]int{0: {1}, 1: {1}}
+		_, err = sortFunc(loop)
+		checkCycle(t, loop, err)
+	}
+
+	if order, _ := TopoSortKahn(dag); !compareSlices(order, []int{4, 5, 6, 0, 2, 3, 1}) {
+		t.Errorf("Алгоритм Кана: неожиданный порядок %v", order)
+	}
+}
+
+// TestTopoSortKahnCycleBehindDAG проверяет поиск цикла, до которого ведут ациклические части графа
+func TestTopoSortKahnCycleBehindDAG(t *testing.T) {
+	graph := map[int][]int{
+		0: {1},
+		1: {2},
+		2: {3},
+		3: {4, 7},
+		4: {5},
+		5: {6},
+		6: {4},
+		7: {},
+	}
+	_, err := TopoSortKahn(graph)
+	checkCycle(t, graph, err)
+	var cycleErr *CycleError
+	if errors.As(err, &cycleErr) && len(cycleErr.Cycle) != 3 {
+		t.Errorf("Ожидался цикл 4 -> 5 -> 6, получено %v", cycleErr.Cycle)
+	}
+}
+
+// undirected строит неориентированный граф из списка рёбер, записывая каждое у обоих концов
+func undirected(edges [][2]int) map[int][]int {
+	graph := make(map[int][]int)
+	for _, e := range edges {
+		graph[e[0]] = append(graph[e[0]], e[1])
+		graph[e[1]] = append(graph[e[1]], e[0])
+	}
+	return graph
+}
+
+func TestMultiSourceBFS(t *testing.T) {
+	// Путь 0-1-2-3-4-5-6 и отдельная вершина 7
+	graph := undirected([][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 4}, {4, 5}, {5, 6}})
+	graph[7] = []int{}
+
+	tree := MultiSourceBFS(graph, []int{0, 6, 0})
+	expectedDist := map[int]int{0: 0, 1: 1, 2: 2, 3: 3, 4: 2, 5: 1, 6: 0}
+	for node, dist := range expectedDist {
+		if tree.Dist[node] != dist {
+			t.Errorf("Вершина %d: ожидалось расстояние %d, получено %d", node, dist, tree.Dist[node])
+		}
+	}
+	if _, ok := tree.Dist[7]; ok {
+		t.Error("Недостижимая вершина 7 не должна попадать в дерево")
+	}
+	if tree.Source[1] != 0 || tree.Source[5] != 6 || tree.Parent[4] != 5 {
+		t.Errorf("Неверные источники или родители: %v, %v", tree.Source, tree.Parent)
+	}
+	if _, ok := tree.Parent[0]; ok {
+		t.Errorf("У источника 0 не должно быть родителя: %v", tree.Parent)
+	}
+	if s := tree.Source[3]; s != 0 && s != 6 {
+		t.Errorf("Вершина 3 равноудалена от источников, получен источник %d", s)
+	}
+
+	if path := tree.PathTo(4); !compareSlices(path, []int{6, 5, 4}) {
+		t.Errorf("Путь до 4: ожидалось [6 5 4], получено %v", path)
+	}
+	if path := tree.PathTo(0); !compareSlices(path, []int{0}) {
+		t.Errorf("Путь до источника: ожидалось [0], получено %v", path)
+	}
+	if path := tree.PathTo(7); path != nil {
+		t.Errorf("Путь до недостижимой вершины: ожидалось nil, получено %v", path)
+	}
+
+	// Отрицательные номера вершин — обычные вершины, а не признак источника
+	negative := undirected([][2]int{{0, -1}, {-1, 2}})
+	if path := MultiSourceBFS(negative, []int{0}).PathTo(2); !compareSlices(path, []int{0, -1, 2}) {
+		t.Errorf("Путь через вершину -1: ожидалось [0 -1 2], получено %v", path)
+	}
+
+	// Расстояния из одного источника совпадают с глубиной обхода BFS
+	single := MultiSourceBFS(graph, []int{2})
+	for node, depth := range BFS(graph, 2).Depth {
+		if single.Dist[node] != depth {
+			t.Errorf("Вершина %d: расстояние %d не совпадает с глубиной BFS %d", node, single.Dist[node], depth)
+		}
+	}
+}
+
+func TestShortestPathTreeSaveToCSV(t *testing.T) {
+	graph := undirected([][2]int{{0, 1}, {1, 2}, {3, 4}})
+	filename := "test_bfs_tree.csv"
+	defer os.Remove(filename)
 
-	f()
+	if err := MultiSourceBFS(graph, []int{2, 4}).SaveToCSV(filename); err != nil {
+		t.Fatalf("Ошибка при записи дерева: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "0,1,2,2\n1,2,1,2\n2,,0,2\n3,4,1,4\n4,,0,4\n"
+	if string(data) != expected {
+		t.Errorf("Неверное содержимое файла:\n%s\nожидалось:\n%s", data, expected)
+	}
+}
 
-	w.Close()
-	os.Stdout = old
+// checkPath проверяет, что path — путь по дугам graph из source в target
+func checkPath(t *testing.T, graph map[int][]int, path []int, source, target int) {
+	t.Helper()
+	if len(path) == 0 || path[0] != source || path[len(path)-1] != target {
+		t.Fatalf("Путь %v не ведёт из %d в %d", path, source, target)
+	}
+	for i := 0; i+1 < len(path); i++ {
+		if !slices.Contains(graph[path[i]], path[i+1]) {
+			t.Errorf("В пути %v нет дуги %d -> %d", path, path[i], path[i+1])
+		}
+	}
+}
 
-	var buf bytes.Buffer
-	buf.ReadFrom(r)
-	return buf.String()
+func TestBidirectionalBFS(t *testing.T) {
+	// Орграф: путь 0 -> 1 -> 2 -> 3 и обход назад 3 -> 4 -> 0; из 3 в 0 можно попасть только через 4
+	digraph := map[int][]int{0: {1}, 1: {2}, 2: {3}, 3: {4}, 4: {0}}
+	reverse := ReverseGraph(digraph)
+	path, explored := BidirectionalBFS(digraph, reverse, 0, 3)
+	if !compareSlices(path, []int{0, 1, 2, 3}) {
+		t.Errorf("Путь 0 -> 3: ожидалось [0 1 2 3], получено %v", path)
+	}
+	if explored == 0 || explored > 5 {
+		t.Errorf("Неверное число раскрытых вершин: %d", explored)
+	}
+	if path, _ := BidirectionalBFS(digraph, reverse, 3, 1); !compareSlices(path, []int{3, 4, 0, 1}) {
+		t.Errorf("Путь 3 -> 1: ожидалось [3 4 0 1], получено %v", path)
+	}
+	if path, explored := BidirectionalBFS(digraph, reverse, 2, 2); !compareSlices(path, []int{2}) || explored != 0 {
+		t.Errorf("Путь из вершины в саму себя: получено %v, раскрыто %d", path, explored)
+	}
+
+	disconnected := map[int][]int{0: {1}, 2: {3}}
+	if path, _ := BidirectionalBFS(disconnected, ReverseGraph(disconnected), 0, 3); path != nil {
+		t.Errorf("Путь между компонентами: ожидалось nil, получено %v", path)
+	}
 }
 
-func parseOutput(output string) []int {
-	var result []int
-	var num int
-	for _, char := range output {
-		if char >= '0' && char <= '9' {
-			num = num*10 + int(char-'0')
-		} else if char == ' ' {
-			if num != 0 {
-				result = append(result, num)
-				num = 0
+// TestBidirectionalBFSMatchesBFS сравнивает длины путей с обычным BFS на случайных графах
+func TestBidirectionalBFSMatchesBFS(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 50; trial++ {
+		const n = 60
+		directed := trial%2 == 0
+		graph := make(map[int][]int)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j && (directed || i < j) && rng.Float64() < 0.04 {
+					graph[i] = append(graph[i], j)
+					if !directed {
+						graph[j] = append(graph[j], i)
+					}
+				}
 			}
 		}
+		reverse := graph
+		if directed {
+			reverse = ReverseGraph(graph)
+		}
+
+		source := rng.Intn(n)
+		depth := BFS(graph, source).Depth
+		for target := 0; target < n; target++ {
+			path, _ := BidirectionalBFS(graph, reverse, source, target)
+			expected, reachable := depth[target]
+			if !reachable {
+				if path != nil {
+					t.Errorf("Вершина %d недостижима из %d, но найден путь %v", target, source, path)
+				}
+				continue
+			}
+			checkPath(t, graph, path, source, target)
+			if len(path)-1 != expected {
+				t.Errorf("Путь %d -> %d: длина %d, кратчайшая %d", source, target, len(path)-1, expected)
+			}
+		}
+	}
+}
+
+// TestBidirectionalBFSExploresLess проверяет, что встречный обход раскрывает меньше вершин, чем полный BFS
+func TestBidirectionalBFSExploresLess(t *testing.T) {
+	// Полное бинарное дерево глубины 12: путь между двумя листьями проходит через корень
+	graph := make(map[int][]int)
+	const size = 1<<13 - 1
+	for child := 1; child < size; child++ {
+		parent := (child - 1) / 2
+		graph[parent] = append(graph[parent], child)
+		graph[child] = append(graph[child], parent)
+	}
+	source, target := size-1, size/2
+	path, explored := BidirectionalBFS(graph, graph, source, target)
+	checkPath(t, graph, path, source, target)
+	if len(path)-1 != 24 {
+		t.Errorf("Ожидался путь длины 24, получено %d", len(path)-1)
 	}
-	if num != 0 {
-		result = append(result, num)
+	if full := len(BFS(graph, source).Order); explored >= full {
+		t.Errorf("Раскрыто %d вершин, полный обход — %d", explored, full)
 	}
-	return result
 }
@@ -0,0 +1,85 @@
+package main
+
+import "pricl_algoritmi/internal/adjacency"
+
+// ReverseGraph возвращает граф с обращёнными дугами. Для неориентированного
+// графа, где каждое ребро записано у обоих концов, он совпадает с исходным,
+// и вместо него можно передавать сам граф.
+func ReverseGraph(graph map[int][]int) map[int][]int {
+	reverse := make(map[int][]int, len(graph))
+	for _, from := range adjacency.SortedVertices(graph) {
+		for _, to := range graph[from] {
+			reverse[to] = append(reverse[to], from)
+		}
+	}
+	return reverse
+}
+
+// BidirectionalBFS ищет кратчайший по числу рёбер путь из source в target
+// встречными обходами в ширину: прямой идёт по graph от source, обратный —
+// по reverse (обращённому графу) от target. На каждом шаге целиком
+// раскрывается меньший из двух фронтов; после уровня, на котором обходы
+// встретились, из всех точек встречи выбирается дающая кратчайший путь.
+// Возвращает путь (nil, если target недостижим) и число раскрытых вершин —
+// тех, чьи списки смежности были просмотрены.
+func BidirectionalBFS(graph, reverse map[int][]int, source, target int) ([]int, int) {
+	if source == target {
+		return []int{source}, 0
+	}
+
+	forward := map[int]int{source: source}  // родитель в прямом обходе
+	backward := map[int]int{target: target} // следующая вершина пути в обратном обходе
+	forwardDist := map[int]int{source: 0}
+	backwardDist := map[int]int{target: 0}
+	forwardFrontier, backwardFrontier := []int{source}, []int{target}
+	explored := 0
+
+	for len(forwardFrontier) > 0 && len(backwardFrontier) > 0 {
+		// Раскрываем меньший фронт
+		adjacency, parent, dist, otherDist := graph, forward, forwardDist, backwardDist
+		frontier := forwardFrontier
+		expandForward := len(forwardFrontier) <= len(backwardFrontier)
+		if !expandForward {
+			adjacency, parent, dist, otherDist = reverse, backward, backwardDist, forwardDist
+			frontier = backwardFrontier
+		}
+
+		meet, best := 0, -1
+		next := []int{}
+		for _, node := range frontier {
+			explored++
+			for _, neighbor := range adjacency[node] {
+				if _, ok := parent[neighbor]; ok {
+					continue
+				}
+				parent[neighbor] = node
+				dist[neighbor] = dist[node] + 1
+				next = append(next, neighbor)
+				if d, ok := otherDist[neighbor]; ok && (best == -1 || dist[neighbor]+d < best) {
+					meet, best = neighbor, dist[neighbor]+d
+				}
+			}
+		}
+		if expandForward {
+			forwardFrontier = next
+		} else {
+			backwardFrontier = next
+		}
+
+		if best != -1 {
+			path := []int{meet}
+			for at := meet; at != source; at = forward[at] {
+				path = append(path, forward[at])
+			}
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			for at := meet; at != target; at = backward[at] {
+				path = append(path, backward[at])
+			}
+			return path, explored
+		}
+	}
+
+	return nil, explored
+}
@@ -0,0 +1,120 @@
+package main
+
+import "pricl_algoritmi/internal/adjacency"
+
+// EdgeKind — класс ребра относительно леса обхода в глубину
+type EdgeKind int
+
+const (
+	TreeEdge    EdgeKind = iota // ребро леса: ведёт в ещё не открытую вершину
+	BackEdge                    // ведёт в предка, обход которого не закончен; признак цикла
+	ForwardEdge                 // ведёт в уже законченного потомка, минуя дерево
+	CrossEdge                   // ведёт в законченную вершину другого поддерева или дерева
+)
+
+func (k EdgeKind) String() string {
+	switch k {
+	case TreeEdge:
+		return "древесное"
+	case BackEdge:
+		return "обратное"
+	case ForwardEdge:
+		return "прямое"
+	case CrossEdge:
+		return "поперечное"
+	}
+	return "неизвестное"
+}
+
+// Visitor — обработчики событий полного обхода в глубину; любой из них может быть nil
+type Visitor struct {
+	OnEnter func(node, time int)              // вершина открыта в момент time
+	OnExit  func(node, time int)              // обход вершины закончен в момент time
+	OnEdge  func(from, to int, kind EdgeKind) // ребро рассмотрено и классифицировано
+}
+
+// DFSForest — результат полного обхода в глубину
+type DFSForest struct {
+	Roots     []int       // корни деревьев в порядке обхода
+	Parent    map[int]int // родитель в лесу обхода; у корней записи нет
+	Discovery map[int]int // момент открытия вершины
+	Finish    map[int]int // момент окончания обхода вершины
+}
+
+// FullDFS обходит в глубину все компоненты ориентированного графа, начиная новые
+// деревья с вершин по возрастанию номеров. Часы идут от 1 и увеличиваются
+// при каждом открытии и закрытии вершины, поэтому интервалы
+// [Discovery, Finish] вершин либо вложены, либо не пересекаются.
+// В неориентированном графе каждое ребро хранится дважды, и второе его
+// появление классифицируется как обратное.
+// Обход итеративный, так что глубина графа не ограничена размером стека вызовов.
+func FullDFS(graph map[int][]int, visitor Visitor) DFSForest {
+	forest := DFSForest{
+		Parent:    make(map[int]int),
+		Discovery: make(map[int]int),
+		Finish:    make(map[int]int),
+	}
+	time := 0
+
+	enter := func(node int) {
+		time++
+		forest.Discovery[node] = time
+		if visitor.OnEnter != nil {
+			visitor.OnEnter(node, time)
+		}
+	}
+	exit := func(node int) {
+		time++
+		forest.Finish[node] = time
+		if visitor.OnExit != nil {
+			visitor.OnExit(node, time)
+		}
+	}
+
+	// Кадр стека — вершина и номер следующего рассматриваемого соседа
+	type frame struct {
+		node, next int
+	}
+	for _, root := range adjacency.SortedVertices(graph) {
+		if _, ok := forest.Discovery[root]; ok {
+			continue
+		}
+		forest.Roots = append(forest.Roots, root)
+		enter(root)
+		stack := []frame{{node: root}}
+
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(graph[top.node]) {
+				stack = stack[:len(stack)-1]
+				exit(top.node)
+				continue
+			}
+			from, to := top.node, graph[top.node][top.next]
+			top.next++
+
+			var kind EdgeKind
+			_, discovered := forest.Discovery[to]
+			_, finished := forest.Finish[to]
+			switch {
+			case !discovered:
+				kind = TreeEdge
+			case !finished:
+				kind = BackEdge
+			case forest.Discovery[from] < forest.Discovery[to]:
+				kind = ForwardEdge
+			default:
+				kind = CrossEdge
+			}
+			if visitor.OnEdge != nil {
+				visitor.OnEdge(from, to, kind)
+			}
+			if kind == TreeEdge {
+				forest.Parent[to] = from
+				enter(to)
+				stack = append(stack, frame{node: to})
+			}
+		}
+	}
+	return forest
+}
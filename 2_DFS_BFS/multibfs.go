@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/csv"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// ShortestPathTree — дерево кратчайших по числу рёбер путей от ближайшего источника.
+// Недостижимых вершин нет ни в одном из отображений.
+type ShortestPathTree struct {
+	Dist   map[int]int // число рёбер до ближайшего источника
+	Parent map[int]int // предыдущая вершина на кратчайшем пути; у источников записи нет
+	Source map[int]int // ближайший источник; при равных расстояниях — один из ближайших
+}
+
+// MultiSourceBFS обходит граф в ширину одновременно из всех sources:
+// все источники кладутся в очередь с расстоянием 0, и каждая вершина
+// достаётся тому источнику, волна от которого дошла до неё первой.
+// Это невзвешенный аналог Dijkstra из 7_Dijkstra.
+func MultiSourceBFS(graph map[int][]int, sources []int) ShortestPathTree {
+	tree := ShortestPathTree{
+		Dist:   make(map[int]int),
+		Parent: make(map[int]int),
+		Source: make(map[int]int),
+	}
+
+	queue := []int{}
+	for _, source := range sources {
+		if _, ok := tree.Dist[source]; ok {
+			continue
+		}
+		tree.Dist[source] = 0
+		tree.Source[source] = source
+		queue = append(queue, source)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, neighbor := range graph[node] {
+			if _, ok := tree.Dist[neighbor]; !ok {
+				tree.Dist[neighbor] = tree.Dist[node] + 1
+				tree.Parent[neighbor] = node
+				tree.Source[neighbor] = tree.Source[node]
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return tree
+}
+
+// PathTo восстанавливает кратчайший путь от ближайшего источника до target;
+// для недостижимой вершины возвращает nil
+func (t ShortestPathTree) PathTo(target int) []int {
+	if _, ok := t.Dist[target]; !ok {
+		return nil
+	}
+	path := make([]int, 0, t.Dist[target]+1)
+	for at, ok := target, true; ok; at, ok = t.Parent[at] {
+		path = append(path, at)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// SaveToCSV сохраняет дерево строками "вершина,родитель,расстояние,источник"
+// по возрастанию номеров вершин; у источников поле родителя пустое
+func (t ShortestPathTree) SaveToCSV(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	for _, node := range slices.Sorted(maps.Keys(t.Dist)) {
+		parent := ""
+		if p, ok := t.Parent[node]; ok {
+			parent = strconv.Itoa(p)
+		}
+		record := []string{
+			strconv.Itoa(node),
+			parent,
+			strconv.Itoa(t.Dist[node]),
+			strconv.Itoa(t.Source[node]),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
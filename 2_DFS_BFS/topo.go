@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"pricl_algoritmi/internal/adjacency"
+)
+
+// CycleError сообщает, что ориентированный граф не ациклический.
+// Cycle — вершины цикла по порядку: из каждой есть дуга в следующую,
+// из последней — в первую; первая вершина в конце не повторяется.
+type CycleError struct {
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	parts := make([]string, 0, len(e.Cycle)+1)
+	for _, node := range e.Cycle {
+		parts = append(parts, fmt.Sprint(node))
+	}
+	if len(e.Cycle) > 0 {
+		parts = append(parts, fmt.Sprint(e.Cycle[0]))
+	}
+	return "граф содержит цикл: " + strings.Join(parts, " -> ")
+}
+
+// TopoSortKahn упорядочивает вершины ориентированного графа алгоритмом Кана:
+// вершины без входящих дуг по очереди выводятся и удаляются из графа.
+// Если граф содержит цикл, возвращается *CycleError с одним из циклов.
+func TopoSortKahn(graph map[int][]int) ([]int, error) {
+	vertices := adjacency.SortedVertices(graph)
+	inDegree := make(map[int]int, len(vertices))
+	for _, neighbors := range graph {
+		for _, neighbor := range neighbors {
+			inDegree[neighbor]++
+		}
+	}
+
+	queue := []int{}
+	for _, node := range vertices {
+		if inDegree[node] == 0 {
+			queue = append(queue, node)
+		}
+	}
+	order := make([]int, 0, len(vertices))
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		order = append(order, node)
+		for _, neighbor := range graph[node] {
+			inDegree[neighbor]--
+			if inDegree[neighbor] == 0 {
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	if len(order) < len(vertices) {
+		return nil, &CycleError{Cycle: remainingCycle(graph, vertices, inDegree)}
+	}
+	return order, nil
+}
+
+// remainingCycle находит цикл среди вершин, которые алгоритм Кана не смог вывести.
+// У каждой такой вершины есть входящая дуга из другой такой же, поэтому, идя
+// от любой из них по входящим дугам, мы обязательно вернёмся в уже пройденную вершину.
+func remainingCycle(graph map[int][]int, vertices []int, inDegree map[int]int) []int {
+	predecessor := make(map[int]int)
+	for _, node := range vertices {
+		if inDegree[node] == 0 {
+			continue
+		}
+		for _, neighbor := range graph[node] {
+			if inDegree[neighbor] > 0 {
+				predecessor[neighbor] = node
+			}
+		}
+	}
+
+	var start int
+	for _, node := range vertices {
+		if inDegree[node] > 0 {
+			start = node
+			break
+		}
+	}
+	position := make(map[int]int)
+	var path []int
+	node := start
+	for {
+		if i, ok := position[node]; ok {
+			path = path[i:]
+			break
+		}
+		position[node] = len(path)
+		path = append(path, node)
+		node = predecessor[node]
+	}
+
+	// Путь шёл против дуг, разворачиваем его
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// TopoSortDFS упорядочивает вершины ориентированного графа по убыванию
+// моментов окончания полного обхода в глубину.
+// Обратное ребро u -> v вместе с путём от v до u по лесу обхода даёт цикл,
+// который возвращается в *CycleError.
+func TopoSortDFS(graph map[int][]int) ([]int, error) {
+	var finished []int
+	var back [2]int
+	hasBack := false
+	forest := FullDFS(graph, Visitor{
+		OnExit: func(node, _ int) { finished = append(finished, node) },
+		OnEdge: func(from, to int, kind EdgeKind) {
+			if kind == BackEdge && !hasBack {
+				back = [2]int{from, to}
+				hasBack = true
+			}
+		},
+	})
+
+	if hasBack {
+		from, to := back[0], back[1]
+		cycle := []int{from}
+		for node := from; node != to; {
+			node = forest.Parent[node]
+			cycle = append(cycle, node)
+		}
+		for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+			cycle[i], cycle[j] = cycle[j], cycle[i]
+		}
+		return nil, &CycleError{Cycle: cycle}
+	}
+
+	for i, j := 0, len(finished)-1; i < j; i, j = i+1, j-1 {
+		finished[i], finished[j] = finished[j], finished[i]
+	}
+	return finished, nil
+}
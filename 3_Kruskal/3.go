@@ -2,12 +2,16 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
 	"sort"
 	"strconv"
 	"time"
+
+	"pricl_algoritmi/internal/seedheader"
+	"pricl_algoritmi/internal/spanningtree"
 )
 
 type Edge struct {
@@ -88,6 +92,7 @@ func ReadGraph(filename string) ([]Edge, []string, error) {
 	defer file.Close()
 
 	reader := csv.NewReader(file)
+	reader.Comment = '#'
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, nil, err
@@ -121,13 +126,19 @@ func ReadGraph(filename string) ([]Edge, []string, error) {
 	return edges, vertexList, nil
 }
 
-func WriteGraph(filename string, edges []Edge) error {
+func WriteGraph(filename string, edges []Edge, header string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	if header != "" {
+		if _, err := fmt.Fprintln(file, header); err != nil {
+			return err
+		}
+	}
+
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
@@ -141,8 +152,8 @@ func WriteGraph(filename string, edges []Edge) error {
 	return nil
 }
 
-func GenerateRandomGraph(numVertices, numEdges int) ([]Edge, []string) {
-	rand.NewSource(time.Now().UnixNano())
+func GenerateRandomGraph(seed int64, numVertices, numEdges int) ([]Edge, []string) {
+	rng := rand.New(rand.NewSource(seed))
 
 	vertices := make([]string, numVertices)
 	for i := 0; i < numVertices; i++ {
@@ -153,8 +164,8 @@ func GenerateRandomGraph(numVertices, numEdges int) ([]Edge, []string) {
 	edgeMap := make(map[string]bool)
 
 	for len(edges) < numEdges {
-		start := vertices[rand.Intn(numVertices)]
-		end := vertices[rand.Intn(numVertices)]
+		start := vertices[rng.Intn(numVertices)]
+		end := vertices[rng.Intn(numVertices)]
 		if start == end {
 			continue
 		}
@@ -166,7 +177,7 @@ func GenerateRandomGraph(numVertices, numEdges int) ([]Edge, []string) {
 			continue
 		}
 
-		weight := rand.Intn(100) + 1
+		weight := rng.Intn(100) + 1
 		edges = append(edges, Edge{Start: start, End: end, Weight: weight})
 		edgeMap[edgeKey1] = true
 		edgeMap[edgeKey2] = true
@@ -175,12 +186,96 @@ func GenerateRandomGraph(numVertices, numEdges int) ([]Edge, []string) {
 	return edges, vertices
 }
 
+// GenerateConnectedGraph строит гарантированно связный граф: сначала случайное
+// остовное дерево, затем случайные рёбра до numEdges
+func GenerateConnectedGraph(seed int64, numVertices, numEdges int) ([]Edge, []string, error) {
+	if numVertices < 1 {
+		return nil, nil, fmt.Errorf("нужна хотя бы одна вершина, получено %d", numVertices)
+	}
+	maxEdges := numVertices * (numVertices - 1) / 2
+	if numEdges < numVertices-1 || numEdges > maxEdges {
+		return nil, nil, fmt.Errorf("связный граф на %d вершинах имеет от %d до %d рёбер, запрошено %d",
+			numVertices, numVertices-1, maxEdges, numEdges)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+
+	vertices := make([]string, numVertices)
+	for i := 0; i < numVertices; i++ {
+		vertices[i] = fmt.Sprintf("V%d", i+1)
+	}
+
+	edges := []Edge{}
+	edgeMap := make(map[string]bool)
+	addEdge := func(start, end string) {
+		weight := rng.Intn(100) + 1
+		edges = append(edges, Edge{Start: start, End: end, Weight: weight})
+		edgeMap[start+"-"+end] = true
+		edgeMap[end+"-"+start] = true
+	}
+
+	for _, e := range spanningtree.Random(rng, numVertices) {
+		addEdge(vertices[e[0]], vertices[e[1]])
+	}
+
+	for len(edges) < numEdges {
+		start := vertices[rng.Intn(numVertices)]
+		end := vertices[rng.Intn(numVertices)]
+		if start == end || edgeMap[start+"-"+end] {
+			continue
+		}
+		addEdge(start, end)
+	}
+
+	return edges, vertices, nil
+}
+
 func main() {
 	numVertices := 9 // Количество вершин
 	numEdges := 13   // Количество рёбер
-	edges, vertices := GenerateRandomGraph(numVertices, numEdges)
 
-	err := WriteGraph("input.csv", edges)
+	seed := flag.Int64("seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	replay := flag.String("replay", "", "CSV-файл, граф из которого нужно воспроизвести по заголовку")
+	connected := flag.Bool("connected", false, "строить гарантированно связный граф")
+	flag.Parse()
+
+	if *replay != "" {
+		s, params, err := seedheader.Read(*replay)
+		if err != nil {
+			fmt.Println("Ошибка при чтении заголовка:", err)
+			return
+		}
+		*seed = s
+		if numVertices, err = strconv.Atoi(params["numVertices"]); err != nil {
+			fmt.Println("Неверное количество вершин в заголовке:", err)
+			return
+		}
+		if numEdges, err = strconv.Atoi(params["numEdges"]); err != nil {
+			fmt.Println("Неверное количество рёбер в заголовке:", err)
+			return
+		}
+		// Заголовки без поля connected записаны до появления связного режима
+		*connected = params["connected"] == "true"
+	}
+
+	var edges []Edge
+	var vertices []string
+	var err error
+	if *connected {
+		edges, vertices, err = GenerateConnectedGraph(*seed, numVertices, numEdges)
+		if err != nil {
+			fmt.Println("Ошибка при генерации графа:", err)
+			return
+		}
+	} else {
+		edges, vertices = GenerateRandomGraph(*seed, numVertices, numEdges)
+	}
+
+	header := seedheader.Format(*seed,
+		"numVertices="+strconv.Itoa(numVertices),
+		"numEdges="+strconv.Itoa(numEdges),
+		"connected="+strconv.FormatBool(*connected))
+	err = WriteGraph("input.csv", edges, header)
 	if err != nil {
 		fmt.Println("Ошибка при записи графа в файл:", err)
 		return
@@ -195,7 +290,7 @@ func main() {
 
 	mst := Kruskal(edges, vertices)
 
-	err = WriteGraph("output.csv", mst)
+	err = WriteGraph("output.csv", mst, "")
 	if err != nil {
 		fmt.Println("Ошибка при записи файла:", err)
 		return
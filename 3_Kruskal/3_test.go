@@ -1,14 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"testing"
+
+	"pricl_algoritmi/internal/seedheader"
 )
 
 func TestGenerateRandomGraph(t *testing.T) {
 	numVertices := 5
 	numEdges := 7
-	edges, vertices := GenerateRandomGraph(numVertices, numEdges)
+	edges, vertices := GenerateRandomGraph(42, numVertices, numEdges)
 
 	if len(vertices) != numVertices {
 		t.Errorf("Ожидалось %d вершин, получено %d", numVertices, len(vertices))
@@ -28,6 +31,47 @@ func TestGenerateRandomGraph(t *testing.T) {
 	}
 }
 
+// TestGenerateRandomGraphReplay проверяет, что граф воспроизводится по заголовку файла.
+func TestGenerateRandomGraphReplay(t *testing.T) {
+	edges, _ := GenerateRandomGraph(7, 9, 13)
+	header := seedheader.Format(7, "numVertices=9", "numEdges=13")
+
+	firstFile := "test_replay_1.csv"
+	if err := WriteGraph(firstFile, edges, header); err != nil {
+		t.Fatalf("Ошибка при записи графа в файл: %v", err)
+	}
+	defer os.Remove(firstFile)
+
+	seed, params, err := seedheader.Read(firstFile)
+	if err != nil {
+		t.Fatalf("Ошибка при чтении заголовка: %v", err)
+	}
+	if seed != 7 || params["numVertices"] != "9" || params["numEdges"] != "13" {
+		t.Fatalf("Неверный заголовок: seed=%d, params=%v", seed, params)
+	}
+
+	replayed, _ := GenerateRandomGraph(seed, 9, 13)
+	secondFile := "test_replay_2.csv"
+	if err := WriteGraph(secondFile, replayed, header); err != nil {
+		t.Fatalf("Ошибка при записи графа в файл: %v", err)
+	}
+	defer os.Remove(secondFile)
+
+	first, _ := os.ReadFile(firstFile)
+	second, _ := os.ReadFile(secondFile)
+	if !bytes.Equal(first, second) {
+		t.Errorf("Воспроизведённый граф отличается от исходного:\n%s\n%s", first, second)
+	}
+
+	readEdges, _, err := ReadGraph(firstFile)
+	if err != nil {
+		t.Fatalf("Ошибка при чтении графа с заголовком: %v", err)
+	}
+	if len(readEdges) != len(edges) {
+		t.Errorf("Ожидалось %d рёбер, получено %d", len(edges), len(readEdges))
+	}
+}
+
 func TestWriteAndReadGraph(t *testing.T) {
 	edges := []Edge{
 		{Start: "V1", End: "V2", Weight: 10},
@@ -37,7 +81,7 @@ func TestWriteAndReadGraph(t *testing.T) {
 	vertices := []string{"V1", "V2", "V3", "V4"}
 
 	testFile := "test_graph.csv"
-	err := WriteGraph(testFile, edges)
+	err := WriteGraph(testFile, edges, "")
 	if err != nil {
 		t.Fatalf("Ошибка при записи графа в файл: %v", err)
 	}
@@ -122,3 +166,30 @@ func TestKruskalWithDisconnectedGraph(t *testing.T) {
 		}
 	}
 }
+
+// TestGenerateConnectedGraph проверяет, что связный режим всегда даёт остовное дерево из n-1 рёбер.
+func TestGenerateConnectedGraph(t *testing.T) {
+	numVertices := 30
+	for seed := int64(0); seed < 20; seed++ {
+		// n-1 рёбер — граф сам является деревом
+		edges, vertices, err := GenerateConnectedGraph(seed, numVertices, numVertices-1)
+		if err != nil {
+			t.Fatalf("Ошибка при генерации графа: %v", err)
+		}
+		if mst := Kruskal(edges, vertices); len(mst) != numVertices-1 {
+			t.Errorf("seed=%d: ожидалось %d рёбер в MST, получено %d", seed, numVertices-1, len(mst))
+		}
+	}
+
+	edges, _, err := GenerateConnectedGraph(1, numVertices, 60)
+	if err != nil {
+		t.Fatalf("Ошибка при генерации графа: %v", err)
+	}
+	if len(edges) != 60 {
+		t.Errorf("Ожидалось 60 рёбер, получено %d", len(edges))
+	}
+
+	if _, _, err := GenerateConnectedGraph(1, numVertices, numVertices-2); err == nil {
+		t.Error("Ожидалась ошибка: связный граф не может иметь меньше n-1 рёбер")
+	}
+}
@@ -2,20 +2,26 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"time"
+
+	"pricl_algoritmi/internal/adjacency"
+	"pricl_algoritmi/internal/biconnected"
+	"pricl_algoritmi/internal/seedheader"
 )
 
-func generateRandomGraph(numVertices int, edgeProbability float64) map[int][]int {
-	rand.NewSource(time.Now().UnixNano())
+func generateRandomGraph(seed int64, numVertices int, edgeProbability float64) map[int][]int {
+	rng := rand.New(rand.NewSource(seed))
 	graph := make(map[int][]int)
 
 	for i := 0; i < numVertices; i++ {
 		for j := i + 1; j < numVertices; j++ {
-			if rand.Float64() < edgeProbability {
+			if rng.Float64() < edgeProbability {
 				graph[i] = append(graph[i], j)
 				graph[j] = append(graph[j], i)
 			}
@@ -25,17 +31,47 @@ func generateRandomGraph(numVertices int, edgeProbability float64) map[int][]int
 	return graph
 }
 
-func writeGraphToCSV(graph map[int][]int, filename string) error {
+// generateRandomDigraph строит ориентированный граф: каждая упорядоченная пара
+// различных вершин соединяется дугой с вероятностью edgeProbability
+func generateRandomDigraph(seed int64, numVertices int, edgeProbability float64) map[int][]int {
+	rng := rand.New(rand.NewSource(seed))
+	graph := make(map[int][]int)
+
+	for i := 0; i < numVertices; i++ {
+		for j := 0; j < numVertices; j++ {
+			if i != j && rng.Float64() < edgeProbability {
+				graph[i] = append(graph[i], j)
+			}
+		}
+	}
+
+	return graph
+}
+
+func writeGraphToCSV(graph map[int][]int, filename, header string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	if header != "" {
+		if _, err := fmt.Fprintln(file, header); err != nil {
+			return err
+		}
+	}
+
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	for vertex, neighbors := range graph {
+	vertices := make([]int, 0, len(graph))
+	for vertex := range graph {
+		vertices = append(vertices, vertex)
+	}
+	sort.Ints(vertices)
+
+	for _, vertex := range vertices {
+		neighbors := graph[vertex]
 		record := []string{strconv.Itoa(vertex)}
 		if len(neighbors) == 0 {
 			record = append(record, "")
@@ -61,6 +97,7 @@ func readGraphFromCSV(filename string) (map[int][]int, error) {
 
 	reader := csv.NewReader(file)
 	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
 	records, err := reader.ReadAll()
 	if err != nil {
 		return nil, err
@@ -114,34 +151,79 @@ func DFS(graph map[int][]int, start int, visited map[int]bool) []int {
 	return component
 }
 
-// Нахождение максимально связанный компонент
+// findLargestConnectedComponent находит наибольшую компоненту слабой связности:
+// направление дуг не учитывается, поэтому для неориентированного графа это
+// обычная компонента связности. Возвращается подграф со всеми дугами компоненты.
 func findLargestConnectedComponent(graph map[int][]int) map[int][]int {
-	visited := make(map[int]bool)
-	var largestComponent map[int][]int
-	maxSize := 0
+	vertices := adjacency.SortedVertices(graph)
+	undirected := make(map[int][]int, len(vertices))
+	for _, from := range vertices {
+		for _, to := range graph[from] {
+			undirected[from] = append(undirected[from], to)
+			undirected[to] = append(undirected[to], from)
+		}
+	}
 
-	for vertex := range graph {
+	visited := make(map[int]bool)
+	var largestComponent []int
+	for _, vertex := range vertices {
 		if !visited[vertex] {
-			componentVertices := DFS(graph, vertex, visited)
-			if len(componentVertices) > maxSize {
-				maxSize = len(componentVertices)
-				largestComponent = make(map[int][]int)
-				for _, v := range componentVertices {
-					largestComponent[v] = graph[v]
-				}
+			componentVertices := DFS(undirected, vertex, visited)
+			if len(componentVertices) > len(largestComponent) {
+				largestComponent = componentVertices
 			}
 		}
 	}
 
-	return largestComponent
+	return inducedSubgraph(graph, largestComponent)
 }
 
 func main() {
 	numVertices := 10
 	edgeProbability := 0.3
-	graph := generateRandomGraph(numVertices, edgeProbability)
 
-	if err := writeGraphToCSV(graph, "input.csv"); err != nil {
+	seed := flag.Int64("seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	replay := flag.String("replay", "", "CSV-файл, граф из которого нужно воспроизвести по заголовку")
+	directed := flag.Bool("directed", false, "генерировать ориентированный граф")
+	mode := flag.String("mode", "strong", "какую компоненту искать: strong — сильной связности, weak — слабой связности (без учёта направления дуг)")
+	flag.Parse()
+
+	if *mode != "strong" && *mode != "weak" {
+		fmt.Println("Неизвестный режим:", *mode)
+		return
+	}
+
+	if *replay != "" {
+		s, params, err := seedheader.Read(*replay)
+		if err != nil {
+			fmt.Println("Ошибка при чтении заголовка:", err)
+			return
+		}
+		*seed = s
+		if numVertices, err = strconv.Atoi(params["numVertices"]); err != nil {
+			fmt.Println("Неверное количество вершин в заголовке:", err)
+			return
+		}
+		if edgeProbability, err = strconv.ParseFloat(params["edgeProbability"], 64); err != nil {
+			fmt.Println("Неверная вероятность ребра в заголовке:", err)
+			return
+		}
+		// Заголовки без поля directed записаны до появления ориентированных графов
+		*directed = params["directed"] == "true"
+	}
+
+	var graph map[int][]int
+	if *directed {
+		graph = generateRandomDigraph(*seed, numVertices, edgeProbability)
+	} else {
+		graph = generateRandomGraph(*seed, numVertices, edgeProbability)
+	}
+
+	header := seedheader.Format(*seed,
+		"numVertices="+strconv.Itoa(numVertices),
+		"edgeProbability="+strconv.FormatFloat(edgeProbability, 'g', -1, 64),
+		"directed="+strconv.FormatBool(*directed))
+	if err := writeGraphToCSV(graph, "input.csv", header); err != nil {
 		fmt.Println("Ошибка при записи графа в файл:", err)
 		return
 	}
@@ -152,9 +234,24 @@ func main() {
 		return
 	}
 
-	largestComponent := findLargestConnectedComponent(readGraph)
+	var largestComponent map[int][]int
+	if *mode == "strong" {
+		scc := TarjanSCC(readGraph)
+		largestComponent = inducedSubgraph(readGraph, scc.Largest())
+		fmt.Printf("Компонент сильной связности: %d, в наибольшей %d вершин\n", len(scc.Components), len(largestComponent))
+	} else {
+		largestComponent = findLargestConnectedComponent(readGraph)
+		fmt.Printf("В наибольшей компоненте слабой связности %d вершин\n", len(largestComponent))
+	}
+
+	if !*directed {
+		// Единые точки отказа: вершины и рёбра, без которых компонента распадается
+		blocks := biconnected.Decompose(largestComponent)
+		fmt.Println("Точки сочленения:", blocks.ArticulationPoints)
+		fmt.Println("Мосты:", blocks.Bridges)
+	}
 
-	if err := writeGraphToCSV(largestComponent, "output.csv"); err != nil {
+	if err := writeGraphToCSV(largestComponent, "output.csv", ""); err != nil {
 		fmt.Println("Ошибка при записи максимальной связной компоненты в файл:", err)
 		return
 	}
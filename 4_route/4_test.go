@@ -44,3 +44,107 @@ func TestFindLargestConnectedComponent(t *testing.T) {
 		}
 	}
 }
+
+// TestFindLargestConnectedComponentDirected проверяет, что слабая связность не зависит от направления дуг
+func TestFindLargestConnectedComponentDirected(t *testing.T) {
+	// Из вершины 0 никуда не ведёт ни одна дуга, но она слабо связана с 1, 2 и 3
+	graph := map[int][]int{
+		1: {0},
+		2: {1},
+		3: {2},
+		4: {5},
+	}
+
+	largestComponent := findLargestConnectedComponent(graph)
+	if len(largestComponent) != 4 {
+		t.Fatalf("Ожидалось 4 вершины в компоненте, получено %d: %v", len(largestComponent), largestComponent)
+	}
+	for vertex := 0; vertex <= 3; vertex++ {
+		if _, ok := largestComponent[vertex]; !ok {
+			t.Errorf("Вершина %d отсутствует в максимальной компоненте", vertex)
+		}
+	}
+	if len(largestComponent[0]) != 0 || len(largestComponent[3]) != 1 || largestComponent[3][0] != 2 {
+		t.Errorf("Дуги компоненты изменились: %v", largestComponent)
+	}
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	graph := map[int][]int{
+		0: {1},
+		1: {2, 3},
+		2: {0},
+		3: {4},
+		4: {5, 7},
+		5: {6},
+		6: {4},
+		7: {8},
+		8: {},
+	}
+	expected := [][]int{{0, 1, 2}, {3}, {4, 5, 6}, {7}, {8}}
+
+	for name, scc := range map[string]SCCResult{
+		"Тарьян":   TarjanSCC(graph),
+		"Косарайю": KosarajuSCC(graph),
+	} {
+		if len(scc.Components) != len(expected) {
+			t.Fatalf("%s: ожидалось %d компонент, получено %v", name, len(expected), scc.Components)
+		}
+		for _, component := range expected {
+			id := scc.Component[component[0]]
+			got := scc.Components[id]
+			if len(got) != len(component) {
+				t.Errorf("%s: компонента вершины %d — ожидалось %v, получено %v", name, component[0], component, got)
+				continue
+			}
+			for i := range component {
+				if got[i] != component[i] || scc.Component[component[i]] != id {
+					t.Errorf("%s: компонента вершины %d — ожидалось %v, получено %v", name, component[0], component, got)
+					break
+				}
+			}
+		}
+
+		// Конденсация — DAG, дуги которого ведут от меньших номеров к большим
+		arcs := 0
+		for from, targets := range scc.Condensation {
+			for _, to := range targets {
+				arcs++
+				if from >= to {
+					t.Errorf("%s: дуга конденсации %d -> %d нарушает топологический порядок", name, from, to)
+				}
+			}
+		}
+		if arcs != 4 {
+			t.Errorf("%s: ожидалось 4 дуги в конденсации, получено %d: %v", name, arcs, scc.Condensation)
+		}
+
+		if largest := scc.Largest(); len(largest) != 3 || largest[0] != 0 {
+			t.Errorf("%s: ожидалась наибольшая компонента [0 1 2], получено %v", name, largest)
+		}
+	}
+
+	subgraph := inducedSubgraph(graph, TarjanSCC(graph).Largest())
+	if len(subgraph) != 3 || len(subgraph[1]) != 1 || subgraph[1][0] != 2 {
+		t.Errorf("Неверный подграф наибольшей компоненты: %v", subgraph)
+	}
+}
+
+// TestSCCAlgorithmsAgree сравнивает разбиения Тарьяна и Косарайю на случайных орграфах
+func TestSCCAlgorithmsAgree(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		graph := generateRandomDigraph(seed, 40, 0.04)
+		tarjan, kosaraju := TarjanSCC(graph), KosarajuSCC(graph)
+		if len(tarjan.Components) != len(kosaraju.Components) {
+			t.Fatalf("seed=%d: число компонент различается: %d и %d", seed, len(tarjan.Components), len(kosaraju.Components))
+		}
+		for u := range tarjan.Component {
+			for v := range tarjan.Component {
+				same := tarjan.Component[u] == tarjan.Component[v]
+				if same != (kosaraju.Component[u] == kosaraju.Component[v]) {
+					t.Fatalf("seed=%d: алгоритмы по-разному разбили вершины %d и %d", seed, u, v)
+				}
+			}
+		}
+	}
+}
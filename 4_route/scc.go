@@ -0,0 +1,218 @@
+package main
+
+import (
+	"sort"
+
+	"pricl_algoritmi/internal/adjacency"
+)
+
+// SCCResult — разбиение ориентированного графа на компоненты сильной связности.
+// Компоненты пронумерованы в топологическом порядке конденсации:
+// каждая дуга между компонентами ведёт от меньшего номера к большему.
+type SCCResult struct {
+	Component    map[int]int   // номер компоненты каждой вершины
+	Components   [][]int       // вершины каждой компоненты по возрастанию
+	Condensation map[int][]int // граф компонент (DAG) без кратных дуг
+}
+
+// newSCCResult нумерует компоненты в порядке components и строит конденсацию
+func newSCCResult(graph map[int][]int, components [][]int) SCCResult {
+	result := SCCResult{
+		Component:    make(map[int]int),
+		Components:   components,
+		Condensation: make(map[int][]int),
+	}
+	for id, component := range components {
+		sort.Ints(component)
+		for _, vertex := range component {
+			result.Component[vertex] = id
+		}
+	}
+
+	arcs := make(map[[2]int]bool)
+	for id := range components {
+		result.Condensation[id] = []int{}
+	}
+	for _, from := range adjacency.SortedVertices(graph) {
+		for _, to := range graph[from] {
+			a, b := result.Component[from], result.Component[to]
+			if a != b && !arcs[[2]int{a, b}] {
+				arcs[[2]int{a, b}] = true
+				result.Condensation[a] = append(result.Condensation[a], b)
+			}
+		}
+	}
+	for id := range result.Condensation {
+		sort.Ints(result.Condensation[id])
+	}
+	return result
+}
+
+// TarjanSCC находит компоненты сильной связности алгоритмом Тарьяна за один
+// обход в глубину: вершина v — корень компоненты, если из её поддерева
+// нельзя подняться выше неё (low[v] == index[v]). Обход итеративный.
+func TarjanSCC(graph map[int][]int) SCCResult {
+	index := make(map[int]int)
+	low := make(map[int]int)
+	onStack := make(map[int]bool)
+	stack := []int{}
+	var components [][]int
+
+	type frame struct {
+		vertex, next int
+	}
+	counter := 0
+	visit := func(vertex int) {
+		index[vertex] = counter
+		low[vertex] = counter
+		counter++
+		stack = append(stack, vertex)
+		onStack[vertex] = true
+	}
+
+	for _, root := range adjacency.SortedVertices(graph) {
+		if _, ok := index[root]; ok {
+			continue
+		}
+		visit(root)
+		frames := []frame{{vertex: root}}
+
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			vertex := top.vertex
+			if top.next < len(graph[vertex]) {
+				neighbor := graph[vertex][top.next]
+				top.next++
+				if _, ok := index[neighbor]; !ok {
+					visit(neighbor)
+					frames = append(frames, frame{vertex: neighbor})
+				} else if onStack[neighbor] {
+					low[vertex] = min(low[vertex], index[neighbor])
+				}
+				continue
+			}
+
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].vertex
+				low[parent] = min(low[parent], low[vertex])
+			}
+			if low[vertex] == index[vertex] {
+				var component []int
+				for {
+					top := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[top] = false
+					component = append(component, top)
+					if top == vertex {
+						break
+					}
+				}
+				components = append(components, component)
+			}
+		}
+	}
+
+	// Тарьян выделяет компоненты в обратном топологическом порядке
+	for i, j := 0, len(components)-1; i < j; i, j = i+1, j-1 {
+		components[i], components[j] = components[j], components[i]
+	}
+	return newSCCResult(graph, components)
+}
+
+// KosarajuSCC находит компоненты сильной связности алгоритмом Косарайю:
+// первый обход в глубину упорядочивает вершины по моменту окончания,
+// второй идёт по обращённому графу в обратном порядке, и каждое его дерево —
+// отдельная компонента.
+func KosarajuSCC(graph map[int][]int) SCCResult {
+	vertices := adjacency.SortedVertices(graph)
+
+	visited := make(map[int]bool)
+	finished := make([]int, 0, len(vertices))
+	type frame struct {
+		vertex, next int
+	}
+	for _, root := range vertices {
+		if visited[root] {
+			continue
+		}
+		visited[root] = true
+		frames := []frame{{vertex: root}}
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			if top.next < len(graph[top.vertex]) {
+				neighbor := graph[top.vertex][top.next]
+				top.next++
+				if !visited[neighbor] {
+					visited[neighbor] = true
+					frames = append(frames, frame{vertex: neighbor})
+				}
+				continue
+			}
+			finished = append(finished, top.vertex)
+			frames = frames[:len(frames)-1]
+		}
+	}
+
+	reversed := make(map[int][]int)
+	for _, from := range vertices {
+		for _, to := range graph[from] {
+			reversed[to] = append(reversed[to], from)
+		}
+	}
+
+	assigned := make(map[int]bool)
+	var components [][]int
+	for i := len(finished) - 1; i >= 0; i-- {
+		root := finished[i]
+		if assigned[root] {
+			continue
+		}
+		assigned[root] = true
+		component := []int{}
+		stack := []int{root}
+		for len(stack) > 0 {
+			vertex := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			component = append(component, vertex)
+			for _, neighbor := range reversed[vertex] {
+				if !assigned[neighbor] {
+					assigned[neighbor] = true
+					stack = append(stack, neighbor)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return newSCCResult(graph, components)
+}
+
+// Largest возвращает вершины наибольшей компоненты; при равных размерах —
+// компоненты с меньшей наименьшей вершиной, чтобы ответ не зависел от алгоритма
+func (r SCCResult) Largest() []int {
+	var largest []int
+	for _, component := range r.Components {
+		if len(component) > len(largest) || len(component) == len(largest) && len(largest) > 0 && component[0] < largest[0] {
+			largest = component
+		}
+	}
+	return largest
+}
+
+// inducedSubgraph возвращает подграф на вершинах vertices со всеми дугами между ними
+func inducedSubgraph(graph map[int][]int, vertices []int) map[int][]int {
+	inside := make(map[int]bool, len(vertices))
+	for _, vertex := range vertices {
+		inside[vertex] = true
+	}
+	subgraph := make(map[int][]int, len(vertices))
+	for _, vertex := range vertices {
+		subgraph[vertex] = []int{}
+		for _, neighbor := range graph[vertex] {
+			if inside[neighbor] {
+				subgraph[vertex] = append(subgraph[vertex], neighbor)
+			}
+		}
+	}
+	return subgraph
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
@@ -9,6 +10,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"time"
+
+	"pricl_algoritmi/internal/seedheader"
 )
 
 type Edge struct {
@@ -102,8 +105,8 @@ func (d *Dinic) MaxFlow(s, t int) int {
 }
 
 // generateNetwork создает сеть с гарантированным путем
-func generateNetwork(numNodes, numEdges int, filename string) {
-	rand.NewSource(time.Now().UnixNano())
+func generateNetwork(seed int64, numNodes, numEdges int, filename string) {
+	rng := rand.New(rand.NewSource(seed))
 
 	// Создаем директории при необходимости
 	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
@@ -116,6 +119,10 @@ func generateNetwork(numNodes, numEdges int, filename string) {
 	}
 	defer file.Close()
 
+	fmt.Fprintln(file, seedheader.Format(seed,
+		"numNodes="+strconv.Itoa(numNodes),
+		"numEdges="+strconv.Itoa(numEdges)))
+
 	writer := csv.NewWriter(file)
 	writer.Comma = ' '
 
@@ -126,7 +133,7 @@ func generateNetwork(numNodes, numEdges int, filename string) {
 	writer.Write([]string{strconv.Itoa(source), strconv.Itoa(sink)})
 
 	// Гарантируем путь от истока к стоку
-	pathLength := 5 + rand.Intn(5)
+	pathLength := 5 + rng.Intn(5)
 	if pathLength > numNodes {
 		pathLength = numNodes - 1
 	}
@@ -137,7 +144,7 @@ func generateNetwork(numNodes, numEdges int, filename string) {
 		if next >= numNodes {
 			next = sink
 		}
-		capacity := 50 + rand.Intn(50) // Пропускная способность 50-100
+		capacity := 50 + rng.Intn(50) // Пропускная способность 50-100
 		writer.Write([]string{
 			strconv.Itoa(prev),
 			strconv.Itoa(next),
@@ -148,12 +155,12 @@ func generateNetwork(numNodes, numEdges int, filename string) {
 	numEdges -= pathLength
 
 	for i := 0; i < numEdges; i++ {
-		from := rand.Intn(numNodes)
-		to := rand.Intn(numNodes)
+		from := rng.Intn(numNodes)
+		to := rng.Intn(numNodes)
 		for to == from || (from == source && to == sink) {
-			to = rand.Intn(numNodes)
+			to = rng.Intn(numNodes)
 		}
-		capacity := 1 + rand.Intn(100) // Пропускная способность 1-100
+		capacity := 1 + rng.Intn(100) // Пропускная способность 1-100
 		writer.Write([]string{
 			strconv.Itoa(from),
 			strconv.Itoa(to),
@@ -173,6 +180,7 @@ func readNetwork(filename string) (int, int, int, [][]int) {
 
 	reader := csv.NewReader(file)
 	reader.Comma = ' '
+	reader.Comment = '#'
 	reader.FieldsPerRecord = -1
 
 	record, err := reader.Read()
@@ -209,7 +217,28 @@ func main() {
 	numEdges := 20000 // Рёбера
 	filename := filepath.Join("network.csv")
 
-	generateNetwork(numNodes, numEdges, filename)
+	seed := flag.Int64("seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	replay := flag.String("replay", "", "CSV-файл, сеть из которого нужно воспроизвести по заголовку")
+	flag.Parse()
+
+	if *replay != "" {
+		s, params, err := seedheader.Read(*replay)
+		if err != nil {
+			fmt.Println("Ошибка при чтении заголовка:", err)
+			return
+		}
+		*seed = s
+		if numNodes, err = strconv.Atoi(params["numNodes"]); err != nil {
+			fmt.Println("Неверное количество узлов в заголовке:", err)
+			return
+		}
+		if numEdges, err = strconv.Atoi(params["numEdges"]); err != nil {
+			fmt.Println("Неверное количество рёбер в заголовке:", err)
+			return
+		}
+	}
+
+	generateNetwork(*seed, numNodes, numEdges, filename)
 
 	n, source, sink, edges := readNetwork(filename)
 
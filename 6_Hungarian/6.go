@@ -1,21 +1,27 @@
 package main
 
 import (
+	"encoding/csv"
+	"flag"
 	"fmt"
 	"math/rand"
+	"os"
+	"strconv"
 	"time"
 
 	hungarian "github.com/oddg/hungarian-algorithm"
+
+	"pricl_algoritmi/internal/seedheader"
 )
 
-func generateRandomGraph(uSize, vSize, edgeProbability int) [][]int {
-	rand.NewSource(time.Now().UnixNano())
+func generateRandomGraph(seed int64, uSize, vSize, edgeProbability int) [][]int {
+	rng := rand.New(rand.NewSource(seed))
 
 	graph := make([][]int, uSize)
 	for i := range graph {
 		graph[i] = make([]int, vSize)
 		for j := range graph[i] {
-			if rand.Intn(100) < edgeProbability {
+			if rng.Intn(100) < edgeProbability {
 				graph[i][j] = 1
 			} else {
 				graph[i][j] = 0
@@ -26,12 +32,83 @@ func generateRandomGraph(uSize, vSize, edgeProbability int) [][]int {
 	return graph
 }
 
+// saveGraphToCSV сохраняет двудольный граф: первая строка — размеры долей,
+// затем по строке "u,v" на каждое ребро
+func saveGraphToCSV(graph [][]int, filename, header string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := fmt.Fprintln(file, header); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	vSize := 0
+	if len(graph) > 0 {
+		vSize = len(graph[0])
+	}
+	if err := writer.Write([]string{strconv.Itoa(len(graph)), strconv.Itoa(vSize)}); err != nil {
+		return err
+	}
+
+	for u := range graph {
+		for v := range graph[u] {
+			if graph[u][v] == 1 {
+				if err := writer.Write([]string{strconv.Itoa(u), strconv.Itoa(v)}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func main() {
-	const uSize = 2000
-	const vSize = 2000
-	const edgeProbability = 30
+	uSize := 2000
+	vSize := 2000
+	edgeProbability := 30
+
+	seed := flag.Int64("seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	replay := flag.String("replay", "", "CSV-файл, граф из которого нужно воспроизвести по заголовку")
+	flag.Parse()
+
+	if *replay != "" {
+		s, params, err := seedheader.Read(*replay)
+		if err != nil {
+			fmt.Println("Ошибка при чтении заголовка:", err)
+			return
+		}
+		*seed = s
+		if uSize, err = strconv.Atoi(params["uSize"]); err != nil {
+			fmt.Println("Неверный размер доли U в заголовке:", err)
+			return
+		}
+		if vSize, err = strconv.Atoi(params["vSize"]); err != nil {
+			fmt.Println("Неверный размер доли V в заголовке:", err)
+			return
+		}
+		if edgeProbability, err = strconv.Atoi(params["edgeProbability"]); err != nil {
+			fmt.Println("Неверная вероятность ребра в заголовке:", err)
+			return
+		}
+	}
+
+	graph := generateRandomGraph(*seed, uSize, vSize, edgeProbability)
 
-	graph := generateRandomGraph(uSize, vSize, edgeProbability)
+	header := seedheader.Format(*seed,
+		"uSize="+strconv.Itoa(uSize),
+		"vSize="+strconv.Itoa(vSize),
+		"edgeProbability="+strconv.Itoa(edgeProbability))
+	if err := saveGraphToCSV(graph, "graph.csv", header); err != nil {
+		fmt.Println("Ошибка при сохранении графа:", err)
+		return
+	}
 
 	costMatrix := make([][]int, uSize)
 	for i := range graph {
@@ -2,12 +2,16 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
 	"os"
 	"strconv"
 	"time"
+
+	"pricl_algoritmi/internal/seedheader"
+	"pricl_algoritmi/internal/spanningtree"
 )
 
 type Edge struct {
@@ -33,13 +37,20 @@ func (g *Graph) AddEdge(from, to, weight int) {
 	g.Edges[from] = append(g.Edges[from], Edge{to, weight})
 }
 
-func (g *Graph) SaveToCSV(filename string) error {
+// SaveToCSV сохраняет граф; непустой header пишется первой строкой-комментарием
+func (g *Graph) SaveToCSV(filename, header string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	if header != "" {
+		if _, err := fmt.Fprintln(file, header); err != nil {
+			return err
+		}
+	}
+
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
@@ -63,14 +74,44 @@ func (g *Graph) SaveToCSV(filename string) error {
 	return nil
 }
 
-func GenerateRandomGraph(vertices int, edgeProbability float64, maxWeight int) *Graph {
-	rand.NewSource(time.Now().UnixNano())
+func GenerateRandomGraph(seed int64, vertices int, edgeProbability float64, maxWeight int) *Graph {
+	rng := rand.New(rand.NewSource(seed))
 	g := NewGraph(vertices)
 
 	for i := 0; i < vertices; i++ {
 		for j := 0; j < vertices; j++ {
-			if i != j && rand.Float64() < edgeProbability {
-				weight := rand.Intn(maxWeight) + 1
+			if i != j && rng.Float64() < edgeProbability {
+				weight := rng.Intn(maxWeight) + 1
+				g.AddEdge(i, j, weight)
+			}
+		}
+	}
+
+	return g
+}
+
+// GenerateConnectedGraph строит сильно связный граф: рёбра случайного остовного
+// дерева добавляются в обоих направлениях, остальные дуги — с такой вероятностью,
+// чтобы ожидаемое число дуг, как и в GenerateRandomGraph, было edgeProbability·n(n-1).
+// Путь между любыми двумя вершинами существует всегда.
+func GenerateConnectedGraph(seed int64, vertices int, edgeProbability float64, maxWeight int) *Graph {
+	rng := rand.New(rand.NewSource(seed))
+	g := NewGraph(vertices)
+
+	inTree := make(map[[2]int]bool)
+	tree := spanningtree.Random(rng, vertices)
+	for _, e := range tree {
+		g.AddEdge(e[0], e[1], rng.Intn(maxWeight)+1)
+		g.AddEdge(e[1], e[0], rng.Intn(maxWeight)+1)
+		inTree[e] = true
+		inTree[[2]int{e[1], e[0]}] = true
+	}
+
+	p := spanningtree.ResidualProbability(edgeProbability, vertices*(vertices-1), 2*len(tree))
+	for i := 0; i < vertices; i++ {
+		for j := 0; j < vertices; j++ {
+			if i != j && !inTree[[2]int{i, j}] && rng.Float64() < p {
+				weight := rng.Intn(maxWeight) + 1
 				g.AddEdge(i, j, weight)
 			}
 		}
@@ -171,9 +212,47 @@ func main() {
 	edgeProbability := 0.3
 	maxWeight := 100
 
-	g := GenerateRandomGraph(vertices, edgeProbability, maxWeight)
+	seed := flag.Int64("seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	replay := flag.String("replay", "", "CSV-файл, граф из которого нужно воспроизвести по заголовку")
+	connected := flag.Bool("connected", false, "строить гарантированно сильно связный граф")
+	flag.Parse()
+
+	if *replay != "" {
+		s, params, err := seedheader.Read(*replay)
+		if err != nil {
+			fmt.Println("Ошибка при чтении заголовка:", err)
+			return
+		}
+		*seed = s
+		if vertices, err = strconv.Atoi(params["vertices"]); err != nil {
+			fmt.Println("Неверное количество вершин в заголовке:", err)
+			return
+		}
+		if edgeProbability, err = strconv.ParseFloat(params["edgeProbability"], 64); err != nil {
+			fmt.Println("Неверная вероятность ребра в заголовке:", err)
+			return
+		}
+		if maxWeight, err = strconv.Atoi(params["maxWeight"]); err != nil {
+			fmt.Println("Неверный максимальный вес в заголовке:", err)
+			return
+		}
+		// Заголовки без поля connected записаны до появления связного режима
+		*connected = params["connected"] == "true"
+	}
+
+	var g *Graph
+	if *connected {
+		g = GenerateConnectedGraph(*seed, vertices, edgeProbability, maxWeight)
+	} else {
+		g = GenerateRandomGraph(*seed, vertices, edgeProbability, maxWeight)
+	}
 
-	err := g.SaveToCSV("graph.csv")
+	header := seedheader.Format(*seed,
+		"vertices="+strconv.Itoa(vertices),
+		"edgeProbability="+strconv.FormatFloat(edgeProbability, 'g', -1, 64),
+		"maxWeight="+strconv.Itoa(maxWeight),
+		"connected="+strconv.FormatBool(*connected))
+	err := g.SaveToCSV("graph.csv", header)
 	if err != nil {
 		fmt.Println("Ошибка при сохранении графа:", err)
 		return
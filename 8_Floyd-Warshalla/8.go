@@ -2,19 +2,22 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
 	"strconv"
 	"time"
+
+	"pricl_algoritmi/internal/seedheader"
 )
 
 const (
 	INF = 999999 // Отсутствия пути
 )
 
-func generateRandomGraph(vertices int, maxWeight int, density float64) [][]int {
-	rand.NewSource(time.Now().UnixNano())
+func generateRandomGraph(seed int64, vertices int, maxWeight int, density float64) [][]int {
+	rng := rand.New(rand.NewSource(seed))
 	g := make([][]int, vertices)
 	for i := range g {
 		g[i] = make([]int, vertices)
@@ -31,10 +34,10 @@ func generateRandomGraph(vertices int, maxWeight int, density float64) [][]int {
 	edges := int(float64(maxEdges) * density)
 
 	for e := 0; e < edges; e++ {
-		i := rand.Intn(vertices)
-		j := rand.Intn(vertices)
+		i := rng.Intn(vertices)
+		j := rng.Intn(vertices)
 		if i != j && g[i][j] == INF {
-			weight := rand.Intn(maxWeight * 2)
+			weight := rng.Intn(maxWeight * 2)
 			g[i][j] = weight
 		} else {
 
@@ -44,13 +47,19 @@ func generateRandomGraph(vertices int, maxWeight int, density float64) [][]int {
 	return g
 }
 
-func saveGraphToCSV(graph [][]int, filename string) error {
+func saveGraphToCSV(graph [][]int, filename, header string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
+	if header != "" {
+		if _, err := fmt.Fprintln(file, header); err != nil {
+			return err
+		}
+	}
+
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
@@ -131,9 +140,38 @@ func main() {
 	maxWeight := 20
 	density := 0.4
 
-	graph := generateRandomGraph(vertices, maxWeight, density)
+	seed := flag.Int64("seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	replay := flag.String("replay", "", "CSV-файл, граф из которого нужно воспроизвести по заголовку")
+	flag.Parse()
+
+	if *replay != "" {
+		s, params, err := seedheader.Read(*replay)
+		if err != nil {
+			fmt.Println("Ошибка при чтении заголовка:", err)
+			return
+		}
+		*seed = s
+		if vertices, err = strconv.Atoi(params["vertices"]); err != nil {
+			fmt.Println("Неверное количество вершин в заголовке:", err)
+			return
+		}
+		if maxWeight, err = strconv.Atoi(params["maxWeight"]); err != nil {
+			fmt.Println("Неверный максимальный вес в заголовке:", err)
+			return
+		}
+		if density, err = strconv.ParseFloat(params["density"], 64); err != nil {
+			fmt.Println("Неверная плотность в заголовке:", err)
+			return
+		}
+	}
+
+	graph := generateRandomGraph(*seed, vertices, maxWeight, density)
 
-	err := saveGraphToCSV(graph, "graph.csv")
+	header := seedheader.Format(*seed,
+		"vertices="+strconv.Itoa(vertices),
+		"maxWeight="+strconv.Itoa(maxWeight),
+		"density="+strconv.FormatFloat(density, 'g', -1, 64))
+	err := saveGraphToCSV(graph, "graph.csv", header)
 	if err != nil {
 		fmt.Println("Ошибка при сохранении графа:", err)
 		return
@@ -2,15 +2,21 @@ package main
 
 import (
 	"encoding/csv"
+	"flag"
 	"fmt"
 	"math/rand"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/plotutil"
 	"gonum.org/v1/plot/vg"
+
+	"pricl_algoritmi/internal/seedheader"
 )
 
 type Graph struct {
@@ -34,8 +40,30 @@ func (g *Graph) AddEdge(u, v int) {
 	g.AdjacencyList[v][u] = true
 }
 
-func GenerateKPartiteGraph(partSizes []int, edgeProbability int) *Graph {
-	rand.NewSource(time.Now().UnixNano())
+// formatPartSizes и parsePartSizes переводят размеры долей в поле заголовка и обратно
+func formatPartSizes(partSizes []int) string {
+	parts := make([]string, len(partSizes))
+	for i, size := range partSizes {
+		parts[i] = strconv.Itoa(size)
+	}
+	return strings.Join(parts, ",")
+}
+
+func parsePartSizes(s string) ([]int, error) {
+	fields := strings.Split(s, ",")
+	partSizes := make([]int, len(fields))
+	for i, field := range fields {
+		size, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		partSizes[i] = size
+	}
+	return partSizes, nil
+}
+
+func GenerateKPartiteGraph(seed int64, partSizes []int, edgeProbability int) *Graph {
+	rng := rand.New(rand.NewSource(seed))
 	graph := NewGraph()
 
 	start := 0
@@ -52,7 +80,7 @@ func GenerateKPartiteGraph(partSizes []int, edgeProbability int) *Graph {
 		for j := i + 1; j < len(partitions); j++ {
 			for _, u := range partitions[i] {
 				for _, v := range partitions[j] {
-					if rand.Intn(100) < edgeProbability {
+					if rng.Intn(100) < edgeProbability {
 						graph.AddEdge(u, v)
 					}
 				}
@@ -63,7 +91,8 @@ func GenerateKPartiteGraph(partSizes []int, edgeProbability int) *Graph {
 	return graph
 }
 
-func (g *Graph) SaveToCSV(filename string) error {
+// SaveToCSV сохраняет рёбра графа; непустой header пишется первой строкой-комментарием
+func (g *Graph) SaveToCSV(filename, header string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return err
@@ -75,11 +104,18 @@ func (g *Graph) SaveToCSV(filename string) error {
 		}
 	}(file)
 
+	if header != "" {
+		if _, err := fmt.Fprintln(file, header); err != nil {
+			return err
+		}
+	}
+
 	writer := csv.NewWriter(file)
 	defer writer.Flush()
 
-	for u, neighbors := range g.AdjacencyList {
-		for v := range neighbors {
+	// Рёбра пишем по возрастанию, чтобы файл не зависел от порядка обхода map
+	for _, u := range sortedKeys(g.AdjacencyList) {
+		for _, v := range sortedKeys(g.AdjacencyList[u]) {
 			if u < v {
 				err := writer.Write([]string{fmt.Sprintf("%d", u), fmt.Sprintf("%d", v)})
 				if err != nil {
@@ -92,6 +128,15 @@ func (g *Graph) SaveToCSV(filename string) error {
 	return nil
 }
 
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
 // Выполнение реберной раскраски графа
 func (g *Graph) EdgeColoring() map[[2]int]int {
 	colors := make(map[[2]int]int)             // Карта для хранения цветов рёбер
@@ -152,16 +197,58 @@ func ValidateEdgeColoring(graph *Graph, colors map[[2]int]int) bool {
 	return true
 }
 
-func VisualizeGraph(graph *Graph, colors map[[2]int]int, numNodes int, filename string) error {
+// ReadCoordinates читает координаты узлов из CSV со строками "узел,x,y",
+// например из файла, который пишет геометрический генератор 1_Rand_graf
+func ReadCoordinates(filename string) (plotter.XYs, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	coords := make(plotter.XYs, len(records))
+	for _, record := range records {
+		if len(record) != 3 {
+			return nil, fmt.Errorf("неверный формат строки координат: %v", record)
+		}
+		node, err := strconv.Atoi(record[0])
+		if err != nil || node < 0 || node >= len(records) {
+			return nil, fmt.Errorf("неверный номер узла: %v", record[0])
+		}
+		if coords[node].X, err = strconv.ParseFloat(record[1], 64); err != nil {
+			return nil, fmt.Errorf("неверная координата x: %v", record[1])
+		}
+		if coords[node].Y, err = strconv.ParseFloat(record[2], 64); err != nil {
+			return nil, fmt.Errorf("неверная координата y: %v", record[2])
+		}
+	}
+	return coords, nil
+}
+
+// VisualizeGraph рисует раскрашенный граф; если coords == nil, узлы расставляются случайно
+func VisualizeGraph(graph *Graph, colors map[[2]int]int, numNodes int, coords plotter.XYs, filename string) error {
 	p := plot.New()
 
 	p.X.Label.Text = "X"
 	p.Y.Label.Text = "Y"
 
-	nodes := make(plotter.XYs, numNodes)
-	for i := 0; i < numNodes; i++ {
-		nodes[i].X = rand.Float64() * 10
-		nodes[i].Y = rand.Float64() * 10
+	if coords != nil && len(coords) < numNodes {
+		return fmt.Errorf("координаты заданы для %d узлов, а в графе их %d", len(coords), numNodes)
+	}
+	nodes := coords
+	if nodes == nil {
+		nodes = make(plotter.XYs, numNodes)
+		for i := 0; i < numNodes; i++ {
+			nodes[i].X = rand.Float64() * 10
+			nodes[i].Y = rand.Float64() * 10
+		}
 	}
 
 	nodePlot, err := plotter.NewScatter(nodes)
@@ -195,13 +282,38 @@ func main() {
 	partSizes := []int{10, 10, 10, 10}
 	edgeProbability := 10
 
-	graph := GenerateKPartiteGraph(partSizes, edgeProbability)
+	seed := flag.Int64("seed", time.Now().UnixNano(), "зерно генератора случайных чисел")
+	replay := flag.String("replay", "", "CSV-файл, граф из которого нужно воспроизвести по заголовку")
+	coordsFile := flag.String("coords", "", "CSV-файл с координатами узлов для визуализации")
+	flag.Parse()
+
+	if *replay != "" {
+		s, params, err := seedheader.Read(*replay)
+		if err != nil {
+			fmt.Println("Ошибка при чтении заголовка:", err)
+			return
+		}
+		*seed = s
+		if partSizes, err = parsePartSizes(params["partSizes"]); err != nil {
+			fmt.Println("Неверные размеры долей в заголовке:", err)
+			return
+		}
+		if edgeProbability, err = strconv.Atoi(params["edgeProbability"]); err != nil {
+			fmt.Println("Неверная вероятность ребра в заголовке:", err)
+			return
+		}
+	}
+
+	graph := GenerateKPartiteGraph(*seed, partSizes, edgeProbability)
 
 	numNodes := 0
 	for _, size := range partSizes {
 		numNodes += size
 	}
-	err := graph.SaveToCSV("color-graph.csv")
+	header := seedheader.Format(*seed,
+		"partSizes="+formatPartSizes(partSizes),
+		"edgeProbability="+strconv.Itoa(edgeProbability))
+	err := graph.SaveToCSV("color-graph.csv", header)
 	if err != nil {
 		fmt.Println("Ошибка при сохранении графа:", err)
 		return
@@ -214,7 +326,15 @@ func main() {
 		return
 	}
 
-	err = VisualizeGraph(graph, colors, numNodes, "graph_vis.png")
+	var coords plotter.XYs
+	if *coordsFile != "" {
+		if coords, err = ReadCoordinates(*coordsFile); err != nil {
+			fmt.Println("Ошибка при чтении координат:", err)
+			return
+		}
+	}
+
+	err = VisualizeGraph(graph, colors, numNodes, coords, "graph_vis.png")
 	if err != nil {
 		fmt.Println("Ошибка при визуализации графа:", err)
 		return
@@ -0,0 +1,22 @@
+// Package adjacency — общие операции над графом, заданным списками смежности.
+package adjacency
+
+import "sort"
+
+// SortedVertices возвращает все вершины графа, включая встречающиеся только
+// в списках соседей, по возрастанию
+func SortedVertices(graph map[int][]int) []int {
+	seen := make(map[int]bool)
+	for node, neighbors := range graph {
+		seen[node] = true
+		for _, neighbor := range neighbors {
+			seen[neighbor] = true
+		}
+	}
+	vertices := make([]int, 0, len(seen))
+	for node := range seen {
+		vertices = append(vertices, node)
+	}
+	sort.Ints(vertices)
+	return vertices
+}
@@ -0,0 +1,17 @@
+package adjacency
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortedVertices(t *testing.T) {
+	// Вершины 7 и -2 встречаются только в списках соседей
+	graph := map[int][]int{3: {7, 1}, 1: {-2}, 5: nil}
+	if got, want := SortedVertices(graph), []int{-2, 1, 3, 5, 7}; !slices.Equal(got, want) {
+		t.Errorf("SortedVertices = %v, ожидалось %v", got, want)
+	}
+	if got := SortedVertices(nil); len(got) != 0 {
+		t.Errorf("Для пустого графа ожидалось пусто, получено %v", got)
+	}
+}
@@ -0,0 +1,156 @@
+// Package biconnected — блоки, точки сочленения и мосты неориентированного графа.
+// Общий код 2_DFS_BFS и 4_route.
+package biconnected
+
+import (
+	"sort"
+
+	"pricl_algoritmi/internal/adjacency"
+)
+
+// Decomposition — разложение неориентированного графа на блоки
+// (компоненты двусвязности) с точками сочленения и мостами
+type Decomposition struct {
+	ArticulationPoints []int    // вершины, удаление которых увеличивает число компонент, по возрастанию
+	Bridges            [][2]int // рёбра (u, v), u < v, удаление которых увеличивает число компонент
+	Blocks             [][]int  // вершины каждого блока по возрастанию; изолированная вершина — отдельный блок
+}
+
+// Decompose находит точки сочленения, мосты и блоки неориентированного графа
+// алгоритмом Хопкрофта–Тарьяна. Граф задан списками смежности, в которых каждое
+// ребро записано у обоих концов; петли не учитываются, кратные рёбра мостами не считаются.
+// Обход в глубину итеративный и работает на графах с миллионами вершин.
+func Decompose(graph map[int][]int) Decomposition {
+	var result Decomposition
+	discovery := make(map[int]int)
+	low := make(map[int]int)
+	isCut := make(map[int]bool)
+	edges := [][2]int{} // стек рёбер текущего блока
+	time := 0
+
+	// Кадр стека: вершина, её родитель в дереве обхода и номер следующего соседа.
+	// Ребро в родителя пропускается один раз, так что параллельное ему ребро
+	// считается обратным.
+	type frame struct {
+		vertex, parent, next int
+		hasParent, skipped   bool
+	}
+
+	popBlock := func(u, v int) {
+		seen := make(map[int]bool)
+		block := []int{}
+		for {
+			edge := edges[len(edges)-1]
+			edges = edges[:len(edges)-1]
+			for _, x := range edge {
+				if !seen[x] {
+					seen[x] = true
+					block = append(block, x)
+				}
+			}
+			if edge == [2]int{u, v} {
+				break
+			}
+		}
+		sort.Ints(block)
+		result.Blocks = append(result.Blocks, block)
+	}
+
+	for _, root := range adjacency.SortedVertices(graph) {
+		if _, ok := discovery[root]; ok {
+			continue
+		}
+		time++
+		discovery[root], low[root] = time, time
+		rootChildren := 0
+		frames := []frame{{vertex: root}}
+
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			v := top.vertex
+			if top.next < len(graph[v]) {
+				w := graph[v][top.next]
+				top.next++
+				switch {
+				case w == v:
+					// петля
+				case top.hasParent && w == top.parent && !top.skipped:
+					top.skipped = true
+				default:
+					if _, ok := discovery[w]; !ok {
+						if !top.hasParent {
+							rootChildren++
+						}
+						edges = append(edges, [2]int{v, w})
+						time++
+						discovery[w], low[w] = time, time
+						frames = append(frames, frame{vertex: w, parent: v, hasParent: true})
+					} else if discovery[w] < discovery[v] {
+						// Обратное ребро в предка; из потомка то же ребро уже учтено
+						edges = append(edges, [2]int{v, w})
+						low[v] = min(low[v], discovery[w])
+					}
+				}
+				continue
+			}
+
+			frames = frames[:len(frames)-1]
+			if len(frames) == 0 {
+				break
+			}
+			u := frames[len(frames)-1].vertex
+			low[u] = min(low[u], low[v])
+			if low[v] > discovery[u] {
+				result.Bridges = append(result.Bridges, [2]int{min(u, v), max(u, v)})
+			}
+			if low[v] >= discovery[u] {
+				// Из поддерева v не подняться выше u: u отделяет блок с ребром (u, v)
+				if u != root {
+					isCut[u] = true
+				}
+				popBlock(u, v)
+			}
+		}
+
+		if rootChildren > 1 {
+			isCut[root] = true
+		}
+		if rootChildren == 0 {
+			result.Blocks = append(result.Blocks, []int{root})
+		}
+	}
+
+	for vertex := range isCut {
+		result.ArticulationPoints = append(result.ArticulationPoints, vertex)
+	}
+	sort.Ints(result.ArticulationPoints)
+	sort.Slice(result.Bridges, func(i, j int) bool {
+		if result.Bridges[i][0] != result.Bridges[j][0] {
+			return result.Bridges[i][0] < result.Bridges[j][0]
+		}
+		return result.Bridges[i][1] < result.Bridges[j][1]
+	})
+	return result
+}
+
+// BlockCutTree строит дерево блоков и точек сочленения (лес, если граф несвязный).
+// Блок i — вершина i дерева, точка сочленения ArticulationPoints[j] — вершина
+// len(Blocks)+j; блок соединён с каждой точкой сочленения, которую содержит.
+func (d Decomposition) BlockCutTree() map[int][]int {
+	cutNode := make(map[int]int, len(d.ArticulationPoints))
+	tree := make(map[int][]int, len(d.Blocks)+len(d.ArticulationPoints))
+	for j, vertex := range d.ArticulationPoints {
+		cutNode[vertex] = len(d.Blocks) + j
+		tree[len(d.Blocks)+j] = []int{}
+	}
+	for i, block := range d.Blocks {
+		tree[i] = []int{}
+		for _, vertex := range block {
+			if node, ok := cutNode[vertex]; ok {
+				tree[i] = append(tree[i], node)
+				tree[node] = append(tree[node], i)
+			}
+		}
+	}
+	return tree
+}
@@ -0,0 +1,121 @@
+package biconnected
+
+import (
+	"slices"
+	"testing"
+)
+
+// undirected строит неориентированный граф из списка рёбер
+func undirected(edges [][2]int) map[int][]int {
+	graph := make(map[int][]int)
+	for _, e := range edges {
+		graph[e[0]] = append(graph[e[0]], e[1])
+		graph[e[1]] = append(graph[e[1]], e[0])
+	}
+	return graph
+}
+
+func TestDecompose(t *testing.T) {
+	// Два треугольника 0-1-2 и 3-4-5, соединённые мостом 2-3, хвост 5-6 и изолированная вершина 7
+	graph := undirected([][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 5}, {5, 3}, {5, 6}})
+	graph[7] = []int{}
+
+	d := Decompose(graph)
+	if !slices.Equal(d.ArticulationPoints, []int{2, 3, 5}) {
+		t.Errorf("Точки сочленения: ожидалось [2 3 5], получено %v", d.ArticulationPoints)
+	}
+	if !slices.Equal(d.Bridges, [][2]int{{2, 3}, {5, 6}}) {
+		t.Errorf("Мосты: ожидалось [[2 3] [5 6]], получено %v", d.Bridges)
+	}
+
+	expectedBlocks := [][]int{{0, 1, 2}, {2, 3}, {3, 4, 5}, {5, 6}, {7}}
+	if len(d.Blocks) != len(expectedBlocks) {
+		t.Fatalf("Ожидалось %d блоков, получено %v", len(expectedBlocks), d.Blocks)
+	}
+	for _, expected := range expectedBlocks {
+		if !slices.ContainsFunc(d.Blocks, func(block []int) bool { return slices.Equal(block, expected) }) {
+			t.Errorf("Блок %v не найден среди %v", expected, d.Blocks)
+		}
+	}
+
+	// 5 блоков и 3 точки сочленения: связная часть — дерево из 7 вершин и 6 рёбер,
+	// изолированная вершина — отдельное дерево
+	tree := d.BlockCutTree()
+	if len(tree) != 8 {
+		t.Fatalf("Ожидалось 8 вершин в дереве блоков, получено %d: %v", len(tree), tree)
+	}
+	degreeSum := 0
+	for _, neighbors := range tree {
+		degreeSum += len(neighbors)
+	}
+	if degreeSum/2 != 6 {
+		t.Errorf("Ожидалось 6 рёбер в дереве блоков, получено %d: %v", degreeSum/2, tree)
+	}
+	for j, vertex := range d.ArticulationPoints {
+		if len(tree[len(d.Blocks)+j]) != 2 {
+			t.Errorf("Точка сочленения %d должна лежать ровно в двух блоках: %v", vertex, tree)
+		}
+	}
+}
+
+// TestDecomposeParallelEdges проверяет, что кратное ребро не считается мостом
+func TestDecomposeParallelEdges(t *testing.T) {
+	graph := undirected([][2]int{{0, 1}, {0, 1}, {1, 2}, {2, 2}})
+	d := Decompose(graph)
+	if !slices.Equal(d.Bridges, [][2]int{{1, 2}}) {
+		t.Errorf("Мосты: ожидалось [[1 2]], получено %v", d.Bridges)
+	}
+	if !slices.Equal(d.ArticulationPoints, []int{1}) {
+		t.Errorf("Точки сочленения: ожидалось [1], получено %v", d.ArticulationPoints)
+	}
+}
+
+// TestDecomposeLongPath проверяет итеративный обход на длинном пути
+func TestDecomposeLongPath(t *testing.T) {
+	const n = 200000
+	graph := make(map[int][]int, n)
+	for i := 0; i+1 < n; i++ {
+		graph[i] = append(graph[i], i+1)
+		graph[i+1] = append(graph[i+1], i)
+	}
+	d := Decompose(graph)
+	if len(d.Bridges) != n-1 || len(d.ArticulationPoints) != n-2 || len(d.Blocks) != n-1 {
+		t.Errorf("Путь из %d вершин: мостов %d, точек сочленения %d, блоков %d",
+			n, len(d.Bridges), len(d.ArticulationPoints), len(d.Blocks))
+	}
+}
+
+func TestDecomposeCycleWithTail(t *testing.T) {
+	// Цикл 0-1-2-3, к вершине 3 подвешен путь 3-4-5
+	graph := map[int][]int{
+		0: {1, 3},
+		1: {0, 2},
+		2: {1, 3},
+		3: {2, 0, 4},
+		4: {3, 5},
+		5: {4},
+	}
+
+	d := Decompose(graph)
+	if len(d.ArticulationPoints) != 2 || d.ArticulationPoints[0] != 3 || d.ArticulationPoints[1] != 4 {
+		t.Errorf("Точки сочленения: ожидалось [3 4], получено %v", d.ArticulationPoints)
+	}
+	if len(d.Bridges) != 2 || d.Bridges[0] != [2]int{3, 4} || d.Bridges[1] != [2]int{4, 5} {
+		t.Errorf("Мосты: ожидалось [[3 4] [4 5]], получено %v", d.Bridges)
+	}
+	if len(d.Blocks) != 3 {
+		t.Errorf("Ожидалось 3 блока, получено %v", d.Blocks)
+	}
+
+	// Дерево блоков — путь: цикл — 3 — мост 3-4 — 4 — мост 4-5
+	tree := d.BlockCutTree()
+	leaves := 0
+	for _, neighbors := range tree {
+		if len(neighbors) == 1 {
+			leaves++
+		}
+	}
+	if len(tree) != 5 || leaves != 2 {
+		t.Errorf("Дерево блоков должно быть путём из 5 вершин: %v", tree)
+	}
+}
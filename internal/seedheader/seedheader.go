@@ -0,0 +1,51 @@
+// Package seedheader — общий для всех программ заголовок файла с графом:
+// строка "# seed=... ключ=значение ...", по которой граф можно сгенерировать заново.
+package seedheader
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Format собирает строку-заголовок с зерном и параметрами генерации
+func Format(seed int64, params ...string) string {
+	fields := append([]string{"seed=" + strconv.FormatInt(seed, 10)}, params...)
+	return "# " + strings.Join(fields, " ")
+}
+
+// Read читает заголовок файла и возвращает зерно и параметры генерации
+func Read(filename string) (int64, map[string]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer file.Close()
+
+	line, err := bufio.NewReader(file).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return 0, nil, err
+	}
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "#") {
+		return 0, nil, fmt.Errorf("в файле %s нет заголовка с параметрами генерации", filename)
+	}
+
+	params := make(map[string]string)
+	for _, field := range strings.Fields(strings.TrimPrefix(line, "#")) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return 0, nil, fmt.Errorf("неверное поле заголовка: %q", field)
+		}
+		params[key] = value
+	}
+
+	seed, err := strconv.ParseInt(params["seed"], 10, 64)
+	if err != nil {
+		return 0, nil, fmt.Errorf("неверное зерно в заголовке: %q", params["seed"])
+	}
+	return seed, params, nil
+}
@@ -0,0 +1,44 @@
+package seedheader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormatAndRead(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "graph.csv")
+	content := Format(7, "n=9", "m=13") + "\n0,1\n"
+	if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	seed, params, err := Read(filename)
+	if err != nil {
+		t.Fatalf("Ошибка при чтении заголовка: %v", err)
+	}
+	if seed != 7 || params["n"] != "9" || params["m"] != "13" {
+		t.Errorf("Неверный заголовок: seed=%d, params=%v", seed, params)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"noheader.csv": "0,1\n",
+		"badfield.csv": "# seed=1 broken\n",
+		"badseed.csv":  "# seed=x n=3\n",
+	}
+	for name, content := range cases {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := Read(filename); err == nil {
+			t.Errorf("%s: ожидалась ошибка", name)
+		}
+	}
+	if _, _, err := Read(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("ожидалась ошибка для отсутствующего файла")
+	}
+}
@@ -0,0 +1,41 @@
+// Package spanningtree — случайные остовные деревья для генераторов
+// гарантированно связных графов.
+package spanningtree
+
+import "math/rand"
+
+// Random возвращает равномерно случайное остовное дерево полного графа
+// на вершинах 0..n-1 (алгоритм Олдоса–Бродера: случайное блуждание, ребро
+// добавляется при первом заходе в вершину)
+func Random(rng *rand.Rand, n int) [][2]int {
+	if n < 2 {
+		return nil
+	}
+	visited := make([]bool, n)
+	current := rng.Intn(n)
+	visited[current] = true
+	tree := make([][2]int, 0, n-1)
+	for len(tree) < n-1 {
+		next := rng.Intn(n - 1)
+		if next >= current {
+			next++
+		}
+		if !visited[next] {
+			visited[next] = true
+			tree = append(tree, [2]int{current, next})
+		}
+		current = next
+	}
+	return tree
+}
+
+// ResidualProbability возвращает вероятность ребра для пар вне дерева, при которой
+// ожидаемое число рёбер всего графа равно p·pairs, а не p·pairs плюс рёбра дерева.
+// Если дерево уже не меньше цели, остальные пары не соединяются.
+func ResidualProbability(p float64, pairs, treeEdges int) float64 {
+	rest := pairs - treeEdges
+	if rest <= 0 {
+		return 0
+	}
+	return min(1, max(0, (p*float64(pairs)-float64(treeEdges))/float64(rest)))
+}
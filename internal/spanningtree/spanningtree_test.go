@@ -0,0 +1,53 @@
+package spanningtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 5, 100} {
+		tree := Random(rand.New(rand.NewSource(3)), n)
+		if want := max(n-1, 0); len(tree) != want {
+			t.Fatalf("n=%d: ожидалось %d рёбер, получено %d", n, want, len(tree))
+		}
+
+		// Дерево из n-1 рёбер без циклов связывает все вершины
+		parent := make([]int, n)
+		for i := range parent {
+			parent[i] = i
+		}
+		var find func(int) int
+		find = func(x int) int {
+			if parent[x] != x {
+				parent[x] = find(parent[x])
+			}
+			return parent[x]
+		}
+		for _, e := range tree {
+			a, b := find(e[0]), find(e[1])
+			if a == b {
+				t.Fatalf("n=%d: ребро %v замыкает цикл", n, e)
+			}
+			parent[a] = b
+		}
+	}
+}
+
+func TestResidualProbability(t *testing.T) {
+	tests := []struct {
+		p                float64
+		pairs, treeEdges int
+		want             float64
+	}{
+		{0.5, 10, 4, 1.0 / 6},
+		{0.1, 10, 4, 0},
+		{1, 10, 4, 1},
+		{0.5, 1, 1, 0},
+	}
+	for _, tt := range tests {
+		if got := ResidualProbability(tt.p, tt.pairs, tt.treeEdges); got != tt.want {
+			t.Errorf("ResidualProbability(%g, %d, %d) = %g, ожидалось %g", tt.p, tt.pairs, tt.treeEdges, got, tt.want)
+		}
+	}
+}